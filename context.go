@@ -0,0 +1,113 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// mapContext implements the core.Context interface for a single call to Mapper.Map.
+type mapContext struct {
+	mapper *Mapper
+	depth  int
+	refs   map[reference]reflect.Value
+	// active holds the references currently being mapped, so that cycles into values are detected.
+	active map[reference]bool
+}
+
+// reference identifies a src pointer mapped into a bare dst type.
+type reference struct {
+	dst reflect.Type
+	src reflect.Type
+	ptr uintptr
+}
+
+// Map implements the core.Context interface.
+func (c *mapContext) Map(dst reflect.Value, src reflect.Value) error {
+	if !dst.IsValid() {
+		return fmt.Errorf("dst must be a non-nil pointer")
+	}
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("dst must be a non-nil pointer, but got %v", dst.Type())
+	}
+	if src.Kind() == reflect.Interface {
+		src = src.Elem()
+	}
+	if !src.IsValid() || (src.Kind() == reflect.Ptr && src.IsNil()) {
+		// There is nothing to map.
+		return nil
+	}
+
+	tm, err := c.mapper.lookup(dst.Type(), src.Type())
+	if err != nil {
+		return err
+	}
+
+	if c.refs != nil && src.Kind() == reflect.Ptr {
+		key, shared, err := c.shareReference(dst, src)
+		if err != nil || shared {
+			return err
+		}
+		if key != (reference{}) {
+			c.active[key] = true
+			defer delete(c.active, key)
+		}
+	}
+
+	src, ok := adaptSrc(src, tm.Src())
+	if !ok {
+		return nil
+	}
+
+	if limit := c.mapper.maxDepth; limit > 0 && c.depth >= limit {
+		return fmt.Errorf("%w: %d", ErrMaxDepthExceeded, limit)
+	}
+	c.depth++
+	defer func() { c.depth-- }()
+
+	return tm.Map(c, adaptDst(dst, tm.Dst()), src)
+}
+
+// shareReference points dst at the value the src pointer was already mapped into, reporting whether it did.
+// Otherwise, it records the value dst points to as the one src is mapped into, and returns the reference for
+// the src. An error wrapping ErrCycle is returned when src is still being mapped and dst holds a value, which
+// cannot share the reference, as mapping it again would never end.
+func (c *mapContext) shareReference(dst reflect.Value, src reflect.Value) (reference, bool, error) {
+	for src.Elem().Kind() == reflect.Ptr && !src.Elem().IsNil() {
+		src = src.Elem()
+	}
+	if src.Elem().Kind() == reflect.Ptr {
+		return reference{}, false, nil
+	}
+
+	key := reference{dst: unwrapPtrType(dst.Type()), src: src.Type(), ptr: src.Pointer()}
+	mapped, seen := c.refs[key]
+
+	// Find the pointer that holds the bare dst value, allocating any pointers before it.
+	for dst.Type().Elem().Kind() == reflect.Ptr {
+		slot := dst.Elem()
+		if slot.Type().Elem().Kind() != reflect.Ptr {
+			if seen {
+				slot.Set(mapped)
+				return key, true, nil
+			}
+			if slot.IsNil() {
+				slot.Set(reflect.New(slot.Type().Elem()))
+			}
+			c.refs[key] = slot.Elem().Addr()
+			return key, false, nil
+		}
+
+		if slot.IsNil() {
+			slot.Set(reflect.New(slot.Type().Elem()))
+		}
+		dst = slot
+	}
+
+	if c.active[key] {
+		return key, false, fmt.Errorf("%w: %v reached again while mapping it into %v", ErrCycle, src.Type(), dst.Type().Elem())
+	}
+	if !seen {
+		c.refs[key] = dst
+	}
+	return key, false, nil
+}
@@ -6,3 +6,10 @@ import (
 
 var ErrNoTypeMapperFound = errors.New("no type mapper found")
 
+// ErrMaxDepthExceeded indicates that nested values were mapped deeper than the limit set by WithMaxDepth.
+var ErrMaxDepthExceeded = errors.New("max depth exceeded")
+
+
+// ErrCycle indicates that, while preserving references, a src pointer was reached again while it was still being
+// mapped, into a dst that holds a value rather than a pointer and so cannot share the reference.
+var ErrCycle = errors.New("reference cycle into a value")
@@ -0,0 +1,22 @@
+package mapper
+
+import (
+	"reflect"
+)
+
+// MapTo maps src into a new Dst, created in the same way as MapNew.
+func MapTo[Dst, Src any](m *Mapper, src Src) (Dst, error) {
+	var dst Dst
+	v, err := m.mapNew(reflect.TypeOf(&dst).Elem(), reflect.ValueOf(src))
+	if err != nil {
+		return dst, err
+	}
+
+	reflect.ValueOf(&dst).Elem().Set(v)
+	return dst, nil
+}
+
+// MapInto maps src into dst.
+func MapInto[Dst, Src any](m *Mapper, dst *Dst, src Src) error {
+	return m.Map(dst, src)
+}
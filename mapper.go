@@ -1,49 +1,199 @@
 package mapper
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/craiggwilson/go-mapper/pkg/core"
 )
 
 // New makes a Mapper.
 func New(providers ...core.Provider) (*Mapper, error) {
-	src := make(map[reflect.Type]map[reflect.Type]core.Mapper)
+	m := Mapper{
+		exact: make(map[typePair]core.Mapper),
+		bare:  make(map[typePair]core.Mapper),
+	}
 	for _, p := range providers {
 		mappers, err := p.Mappers()
 		if err != nil {
 			return nil, err
 		}
 		for _, tm := range mappers {
-			dstMap, ok := src[tm.Src()]
-			if !ok {
-				dstMap = make(map[reflect.Type]core.Mapper)
-				src[tm.Src()] = dstMap
-			}
-
-			dstMap[tm.Dst()] = tm
+			m.exact[typePair{tm.Dst(), tm.Src()}] = tm
+			m.bare[typePair{unwrapPtrType(tm.Dst()), unwrapPtrType(tm.Src())}] = tm
+			m.mappers = append(m.mappers, tm)
 		}
 	}
 
-	return &Mapper{
-		src: src,
-	}, nil
+	return &m, nil
 }
 
+// Mapper maps values using the core.Mappers supplied by its providers.
 type Mapper struct {
-	src map[reflect.Type]map[reflect.Type]core.Mapper
+	exact   map[typePair]core.Mapper
+	bare    map[typePair]core.Mapper
+	mappers []core.Mapper
+	// resolved caches the mappers found by resolve, holding nil for types with none.
+	resolved sync.Map
+
+	assignableDst      bool
+	constructors       map[reflect.Type]reflect.Value
+	maxDepth           int
+	preserveReferences bool
+}
+
+// WithAssignableDst enables resolving a mapper whose destination type is assignable to the requested
+// destination type, such as a concrete type for an interface. It must be called before the Mapper is used.
+func (m *Mapper) WithAssignableDst(enabled bool) {
+	m.assignableDst = enabled
+	// Mappers resolved under the previous setting may no longer apply.
+	m.resolved.Range(func(key, _ interface{}) bool {
+		m.resolved.Delete(key)
+		return true
+	})
 }
 
+// WithConstructor registers fn to create the values of its result type for MapNew, such as for types with
+// invariants or unexported fields that must be initialized. The fn must be a func with no parameters returning
+// the value and optionally an error, such as func() (*Order, error). A constructor for *T is also used for T,
+// and one for T is also used for *T. It must be called before the Mapper is used.
+func (m *Mapper) WithConstructor(fn interface{}) {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.NumOut() < 1 || t.NumOut() > 2 ||
+		(t.NumOut() == 2 && t.Out(1) != tError) {
+		panic(fmt.Errorf("fn argument must be a func with no parameters returning a value and optionally an error, but got %v", t))
+	}
+
+	if m.constructors == nil {
+		m.constructors = make(map[reflect.Type]reflect.Value)
+	}
+	m.constructors[t.Out(0)] = v
+}
+
+// WithMaxDepth limits how deeply nested values are mapped, returning an error wrapping ErrMaxDepthExceeded
+// rather than recursing without end. A depth of 0, the default, is unlimited.
+func (m *Mapper) WithMaxDepth(depth int) {
+	m.maxDepth = depth
+}
+
+// WithPreserveReferences enables an identity map for each call to Map, so that a src pointer reached more
+// than once maps to the same dst pointer each time. Shared references are then shared in the dst, and cycles
+// are reproduced rather than followed forever.
+func (m *Mapper) WithPreserveReferences(enabled bool) {
+	m.preserveReferences = enabled
+}
+
+// Map maps the src into the dst. Each core.Mapper is handed a core.Context that can be used to map
+// nested values with any of the other registered mappers.
 func (m *Mapper) Map(dst interface{}, src interface{}) error {
-	vDst := reflect.ValueOf(dst)
-	vSrc := reflect.ValueOf(src)
-	if dstMap, ok := m.src[vSrc.Type()]; ok {
-		if tm, ok := dstMap[vDst.Type()]; ok {
-			return tm.Map(nil, vDst, vSrc)
+	return m.mapValue(reflect.ValueOf(dst), reflect.ValueOf(src))
+}
+
+func (m *Mapper) mapValue(dst reflect.Value, src reflect.Value) error {
+	ctx := &mapContext{mapper: m}
+	if m.preserveReferences {
+		ctx.refs = make(map[reference]reflect.Value)
+		ctx.active = make(map[reference]bool)
+	}
+	return ctx.Map(dst, src)
+}
+
+// MapNew maps the src into a new value of the dst type, which is returned. The value is created by the
+// constructor registered with WithConstructor, when there is one, and is otherwise the zero value, with any
+// pointer allocated.
+func (m *Mapper) MapNew(dst reflect.Type, src interface{}) (interface{}, error) {
+	v, err := m.mapNew(dst, reflect.ValueOf(src))
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+func (m *Mapper) mapNew(dst reflect.Type, src reflect.Value) (reflect.Value, error) {
+	v, err := m.construct(dst)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	p := reflect.New(dst)
+	p.Elem().Set(v)
+	if err = m.mapValue(p, src); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return p.Elem(), nil
+}
+
+// construct creates a new value of type t using a registered constructor, or returns the zero value.
+func (m *Mapper) construct(t reflect.Type) (reflect.Value, error) {
+	fn, ok := m.constructors[t]
+	switch {
+	case ok:
+		return call(fn, t)
+	case t.Kind() == reflect.Ptr:
+		if fn, ok = m.constructors[t.Elem()]; ok {
+			v, err := call(fn, t)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return addr(v), nil
+		}
+	default:
+		if fn, ok = m.constructors[reflect.PtrTo(t)]; ok {
+			v, err := call(fn, t)
+			if err != nil || v.IsNil() {
+				return reflect.Zero(t), err
+			}
+			return v.Elem(), nil
 		}
 	}
 
-	return ErrNoTypeMapperFound
+	return reflect.Zero(t), nil
 }
 
+// call calls the constructor fn for the type t.
+func call(fn reflect.Value, t reflect.Type) (reflect.Value, error) {
+	out := fn.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("constructing %v: %w", t, out[1].Interface().(error))
+	}
 
+	return out[0], nil
+}
+
+// lookup resolves the core.Mapper for the dst and src types. When more than one mapper could be used, the
+// first of the following is chosen:
+//  1. the mapper registered for exactly the dst and src types.
+//  2. the mapper registered for the dst and src types once all pointers are removed.
+//  3. the first registered mapper for the dst type whose src type is an interface implemented by the src type.
+//  4. when enabled by WithAssignableDst, the first registered mapper, matching the src type as in 2 or 3,
+//     whose dst type is assignable to the dst type.
+//
+// Registering more than one mapper for the same types in 1 or 2 results in the last one being used.
+func (m *Mapper) lookup(dst reflect.Type, src reflect.Type) (core.Mapper, error) {
+	if tm, ok := m.exact[typePair{dst, src}]; ok {
+		return tm, nil
+	}
+
+	bareDst := unwrapPtrType(dst)
+	bareSrc := unwrapPtrType(src)
+	if tm, ok := m.bare[typePair{bareDst, bareSrc}]; ok {
+		return tm, nil
+	}
+
+	if tm := m.resolve(dst, src); tm != nil {
+		return tm, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v from %v", ErrNoTypeMapperFound, dst, src)
+}
+
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+type typePair struct {
+	dst reflect.Type
+	src reflect.Type
+}
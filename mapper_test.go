@@ -0,0 +1,377 @@
+package mapper_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/craiggwilson/go-mapper"
+	"github.com/craiggwilson/go-mapper/pkg/auto"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/static"
+)
+
+func TestMapNested(t *testing.T) {
+	t.Parallel()
+	type customer struct {
+		Name string
+	}
+	type order struct {
+		Customer *customer
+	}
+	type customerDTO struct {
+		Name string
+	}
+	type orderDTO struct {
+		Customer *customerDTO
+	}
+
+	sp := static.NewProvider()
+	sp.Add(core.MapperFromFunc(func(dst *customerDTO, src *customer) error {
+		dst.Name = src.Name
+		return nil
+	}))
+	sp.Add(core.MapperFromFunc(func(ctx core.Context, dst *orderDTO, src *order) error {
+		dst.Customer = &customerDTO{}
+		return ctx.Map(reflect.ValueOf(dst.Customer), reflect.ValueOf(src.Customer))
+	}))
+
+	m, err := mapper.New(sp)
+	require.NoError(t, err)
+
+	src := order{
+		Customer: &customer{
+			Name: "Blockus",
+		},
+	}
+	var dst orderDTO
+	err = m.Map(&dst, &src)
+	require.NoError(t, err)
+	require.NotNil(t, dst.Customer)
+	require.Equal(t, src.Customer.Name, dst.Customer.Name)
+}
+
+func TestMapNoTypeMapperFound(t *testing.T) {
+	t.Parallel()
+	type order struct{}
+	type orderDTO struct{}
+
+	m, err := mapper.New(static.NewProvider())
+	require.NoError(t, err)
+
+	err = m.Map(&orderDTO{}, &order{})
+	require.True(t, errors.Is(err, mapper.ErrNoTypeMapperFound))
+}
+
+func TestMapGeneric(t *testing.T) {
+	t.Parallel()
+	type customer struct {
+		Name string
+	}
+	type customerDTO struct {
+		Name string
+	}
+
+	ap := auto.NewProvider()
+	auto.Register[customerDTO, customer](ap)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	src := customer{
+		Name: "Blockus",
+	}
+
+	t.Run("MapTo", func(t *testing.T) {
+		dst, err := mapper.MapTo[customerDTO](m, src)
+		require.NoError(t, err)
+		require.Equal(t, src.Name, dst.Name)
+	})
+
+	t.Run("MapTo pointer", func(t *testing.T) {
+		dst, err := mapper.MapTo[*customerDTO](m, &src)
+		require.NoError(t, err)
+		require.NotNil(t, dst)
+		require.Equal(t, src.Name, dst.Name)
+	})
+
+	t.Run("MapInto", func(t *testing.T) {
+		var dst customerDTO
+		err := mapper.MapInto(m, &dst, &src)
+		require.NoError(t, err)
+		require.Equal(t, src.Name, dst.Name)
+	})
+}
+
+type named interface {
+	FullName() string
+}
+
+type person struct {
+	First string
+	Last  string
+}
+
+func (p *person) FullName() string {
+	return p.First + " " + p.Last
+}
+
+type labeled interface {
+	Label() string
+}
+
+type personDTO struct {
+	Name string
+}
+
+func (p personDTO) Label() string {
+	return p.Name
+}
+
+func TestMapResolution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pointer depth", func(t *testing.T) {
+		sp := static.NewProvider()
+		sp.Add(core.MapperFromFunc(func(dst *personDTO, src *person) error {
+			dst.Name = src.First
+			return nil
+		}))
+
+		m, err := mapper.New(sp)
+		require.NoError(t, err)
+
+		src := person{First: "Blockus"}
+
+		var dst personDTO
+		require.NoError(t, m.Map(&dst, src))
+		require.Equal(t, "Blockus", dst.Name)
+
+		var pdst *personDTO
+		require.NoError(t, m.Map(&pdst, &src))
+		require.NotNil(t, pdst)
+		require.Equal(t, "Blockus", pdst.Name)
+
+		psrc := &src
+		dst = personDTO{}
+		require.NoError(t, m.Map(&dst, &psrc))
+		require.Equal(t, "Blockus", dst.Name)
+
+		dst = personDTO{}
+		require.NoError(t, m.Map(&dst, (*person)(nil)))
+		require.Empty(t, dst.Name)
+
+		require.Error(t, m.Map(dst, &src))
+	})
+
+	t.Run("exact before bare", func(t *testing.T) {
+		sp := static.NewProvider()
+		sp.Add(core.MapperFromFunc(func(dst *personDTO, src *person) error {
+			dst.Name = "exact"
+			return nil
+		}))
+		sp.Add(core.MapperFromFunc(func(dst *personDTO, src person) error {
+			dst.Name = "bare"
+			return nil
+		}))
+
+		m, err := mapper.New(sp)
+		require.NoError(t, err)
+
+		var dst personDTO
+		require.NoError(t, m.Map(&dst, &person{}))
+		require.Equal(t, "exact", dst.Name)
+	})
+
+	t.Run("src interface", func(t *testing.T) {
+		sp := static.NewProvider()
+		sp.Add(core.MapperFromFunc(func(dst *personDTO, src named) error {
+			dst.Name = src.FullName()
+			return nil
+		}))
+
+		m, err := mapper.New(sp)
+		require.NoError(t, err)
+
+		var dst personDTO
+		require.NoError(t, m.Map(&dst, person{First: "Blockus", Last: "Jones"}))
+		require.Equal(t, "Blockus Jones", dst.Name)
+
+		// Resolved again from the cache.
+		require.NoError(t, m.Map(&dst, person{First: "Ada", Last: "Lovelace"}))
+		require.Equal(t, "Ada Lovelace", dst.Name)
+
+		var other labeled
+		for i := 0; i < 2; i++ {
+			err = m.Map(&other, person{})
+			require.True(t, errors.Is(err, mapper.ErrNoTypeMapperFound))
+		}
+	})
+
+	t.Run("assignable dst", func(t *testing.T) {
+		sp := static.NewProvider()
+		sp.Add(core.MapperFromFunc(func(dst *personDTO, src *person) error {
+			dst.Name = src.First
+			return nil
+		}))
+
+		m, err := mapper.New(sp)
+		require.NoError(t, err)
+
+		var dst labeled
+		err = m.Map(&dst, &person{First: "Blockus"})
+		require.True(t, errors.Is(err, mapper.ErrNoTypeMapperFound))
+
+		m.WithAssignableDst(true)
+		require.NoError(t, m.Map(&dst, &person{First: "Blockus"}))
+		require.Equal(t, personDTO{Name: "Blockus"}, dst)
+	})
+}
+
+func TestMapReferences(t *testing.T) {
+	t.Parallel()
+	type employee struct {
+		Name    string
+		Manager *employee
+		Reports []*employee
+	}
+	type employeeDTO struct {
+		Name    string
+		Manager *employeeDTO
+		Reports []*employeeDTO
+	}
+
+	boss := &employee{Name: "boss"}
+	alice := &employee{Name: "alice", Manager: boss}
+	bob := &employee{Name: "bob", Manager: boss}
+	boss.Reports = []*employee{alice, bob}
+	boss.Manager = boss
+
+	newMapper := func(t *testing.T) *mapper.Mapper {
+		ap := auto.NewProvider()
+		auto.Register[employeeDTO, employee](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("preserved", func(t *testing.T) {
+		m := newMapper(t)
+		m.WithPreserveReferences(true)
+
+		var dst employeeDTO
+		require.NoError(t, m.Map(&dst, boss))
+		require.Equal(t, "boss", dst.Name)
+		require.Same(t, &dst, dst.Manager)
+		require.Len(t, dst.Reports, 2)
+		require.Equal(t, "alice", dst.Reports[0].Name)
+		require.Same(t, &dst, dst.Reports[0].Manager)
+		require.Same(t, dst.Reports[0].Manager, dst.Reports[1].Manager)
+	})
+
+	t.Run("cycle into values", func(t *testing.T) {
+		type employeeValueDTO struct {
+			Name    string
+			Reports []employeeValueDTO
+		}
+
+		ap := auto.NewProvider()
+		auto.Register[employeeValueDTO, employee](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+		m.WithPreserveReferences(true)
+
+		self := &employee{Name: "self"}
+		self.Reports = []*employee{self}
+
+		var dst employeeValueDTO
+		err = m.Map(&dst, self)
+		require.True(t, errors.Is(err, mapper.ErrCycle), "expected ErrCycle, but got %v", err)
+
+		shared := &employee{Name: "shared"}
+		dst = employeeValueDTO{}
+		require.NoError(t, m.Map(&dst, &employee{Name: "boss", Reports: []*employee{shared, shared}}))
+		require.Equal(t, []employeeValueDTO{{Name: "shared"}, {Name: "shared"}}, dst.Reports)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		m := newMapper(t)
+		m.WithMaxDepth(10)
+
+		var dst employeeDTO
+		err := m.Map(&dst, boss)
+		require.True(t, errors.Is(err, mapper.ErrMaxDepthExceeded), "expected ErrMaxDepthExceeded, but got %v", err)
+	})
+
+	t.Run("max depth not reached", func(t *testing.T) {
+		m := newMapper(t)
+		m.WithMaxDepth(3)
+
+		var dst employeeDTO
+		require.NoError(t, m.Map(&dst, &employee{Name: "carol", Manager: &employee{Name: "dave"}}))
+		require.Equal(t, "dave", dst.Manager.Name)
+	})
+}
+
+func TestMapNew(t *testing.T) {
+	t.Parallel()
+	type customer struct {
+		Name string
+	}
+	type account struct {
+		Name    string
+		created bool
+	}
+
+	ap := auto.NewProvider()
+	auto.Register[account, customer](ap)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+	m.WithConstructor(func() *account {
+		return &account{created: true}
+	})
+
+	src := customer{Name: "Blockus"}
+
+	t.Run("value", func(t *testing.T) {
+		dst, err := m.MapNew(reflect.TypeOf(account{}), &src)
+		require.NoError(t, err)
+		require.Equal(t, account{Name: "Blockus", created: true}, dst)
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		dst, err := m.MapNew(reflect.TypeOf(&account{}), src)
+		require.NoError(t, err)
+		require.Equal(t, &account{Name: "Blockus", created: true}, dst)
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		dst, err := mapper.MapTo[*account](m, src)
+		require.NoError(t, err)
+		require.Equal(t, &account{Name: "Blockus", created: true}, dst)
+	})
+
+	t.Run("without constructor", func(t *testing.T) {
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		dst, err := m.MapNew(reflect.TypeOf(&account{}), src)
+		require.NoError(t, err)
+		require.Equal(t, &account{Name: "Blockus"}, dst)
+	})
+
+	t.Run("constructor error", func(t *testing.T) {
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+		failure := errors.New("no accounts")
+		m.WithConstructor(func() (account, error) {
+			return account{}, failure
+		})
+
+		_, err = m.MapNew(reflect.TypeOf(account{}), src)
+		require.True(t, errors.Is(err, failure), "expected %v, but got %v", failure, err)
+	})
+}
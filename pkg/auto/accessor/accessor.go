@@ -13,3 +13,27 @@ type Accessor interface {
 	// ValueFrom retrieves a value from the provided value.
 	ValueFrom(v reflect.Value) reflect.Value
 }
+
+// ErrAccessor is implemented by accessors that can fail to retrieve a value, such as one calling a method that
+// returns an error.
+type ErrAccessor interface {
+	Accessor
+	// ValueFromErr retrieves a value from the provided value, or returns an error when it cannot be retrieved.
+	ValueFromErr(v reflect.Value) (reflect.Value, error)
+}
+
+// ValueFrom retrieves a value from v using a, returning the error from a when it is an ErrAccessor.
+func ValueFrom(a Accessor, v reflect.Value) (reflect.Value, error) {
+	if ea, ok := a.(ErrAccessor); ok {
+		return ea.ValueFromErr(v)
+	}
+	return a.ValueFrom(v), nil
+}
+
+// mustValue returns v, panicking with err when there is one. It serves the ValueFrom method of an ErrAccessor.
+func mustValue(v reflect.Value, err error) reflect.Value {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
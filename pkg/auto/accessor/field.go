@@ -2,6 +2,8 @@ package accessor
 
 import (
 	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
 )
 
 // Field returns an accessor for a struct field.
@@ -26,9 +28,20 @@ func (a *FieldAccessor) Type() reflect.Type {
 
 // ValueFrom implements the Accessor interface.
 func (a *FieldAccessor) ValueFrom(v reflect.Value) reflect.Value {
-	if v.IsNil() {
+	if internal.IsNil(v) {
 		return v
 	}
 
-	return reflect.Indirect(v).FieldByIndex(a.fld.Index)
+	fv, err := reflect.Indirect(v).FieldByIndexErr(a.fld.Index)
+	if err != nil {
+		// A field promoted through a nil embedded pointer reads as the zero value.
+		return reflect.Zero(a.fld.Type)
+	}
+
+	return fv
+}
+
+// Field returns the struct field that is accessed.
+func (a *FieldAccessor) Field() reflect.StructField {
+	return a.fld
 }
@@ -0,0 +1,148 @@
+package accessor
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/naming"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// MapKey returns an accessor for the value in a map with string keys known by name, where elem is the element
+// type of the map. Keys are found when the naming strategy offers them, ignoring case, underscores and dashes,
+// such that the key first_name provides FirstName. When elem is an interface, flattened names are found in
+// nested maps, such that CustomerName is provided by the key name in the map under the key customer.
+func MapKey(ns naming.Strategy, name string, elem reflect.Type) *MapKeyAccessor {
+	return &MapKeyAccessor{ns: ns, name: name, elem: elem}
+}
+
+// MapKeyAccessor retrieves a value from a map with string keys.
+type MapKeyAccessor struct {
+	ns   naming.Strategy
+	name string
+	elem reflect.Type
+}
+
+// Name implements the Accessor interface.
+func (a *MapKeyAccessor) Name() string {
+	return a.name
+}
+
+// Type implements the Accessor interface.
+func (a *MapKeyAccessor) Type() reflect.Type {
+	return a.elem
+}
+
+// ValueFrom implements the Accessor interface. It panics when more than one key matches the name, for which
+// ValueFromErr returns an error instead.
+func (a *MapKeyAccessor) ValueFrom(v reflect.Value) reflect.Value {
+	return mustValue(a.ValueFromErr(v))
+}
+
+// ValueFromErr implements the ErrAccessor interface. A missing key results in an invalid value, and more than one
+// key matching the name when ignoring case, underscores and dashes results in an error.
+func (a *MapKeyAccessor) ValueFromErr(v reflect.Value) (reflect.Value, error) {
+	if internal.IsNil(v) {
+		return v, nil
+	}
+
+	found, _, err := a.lookup(v, a.name)
+	return found, err
+}
+
+// KeyPath returns the path of keys leading to the value in the map v, such as ["customer"]["name"], or the name
+// of the accessor when there is no such value.
+func (a *MapKeyAccessor) KeyPath(v reflect.Value) string {
+	_, keys, err := a.lookup(v, a.name)
+	if err != nil || len(keys) == 0 {
+		return "." + a.name
+	}
+
+	var sb strings.Builder
+	for _, key := range keys {
+		sb.WriteString("[" + strconv.Quote(key) + "]")
+	}
+	return sb.String()
+}
+
+// lookup returns the value in the map v for name, along with the keys leading to it, which are empty when there
+// is no such value.
+func (a *MapKeyAccessor) lookup(v reflect.Value, name string) (reflect.Value, []string, error) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, nil, nil
+	}
+
+	for _, p := range a.ns.Possibilities(name) {
+		found, key, err := mapIndex(v, p.Match)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
+		if !found.IsValid() {
+			continue
+		}
+		if p.Remaining == "" {
+			return found, []string{key}, nil
+		}
+		if a.elem.Kind() != reflect.Interface {
+			continue
+		}
+		nested, keys, err := a.lookup(found, p.Remaining)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
+		if len(keys) > 0 {
+			return nested, append([]string{key}, keys...), nil
+		}
+	}
+
+	return reflect.Value{}, nil, nil
+}
+
+// mapIndex returns the value in the map v under the key name, or otherwise under the only key matching name when
+// ignoring case, underscores and dashes, along with the key. The value is invalid when there is no such key.
+func mapIndex(v reflect.Value, name string) (reflect.Value, string, error) {
+	key := reflect.ValueOf(name).Convert(v.Type().Key())
+	if found := v.MapIndex(key); found.IsValid() {
+		return found, name, nil
+	}
+
+	normalized := normalizeKey(name)
+	var matches []string
+	iter := v.MapRange()
+	for iter.Next() {
+		if normalizeKey(iter.Key().String()) == normalized {
+			matches = append(matches, iter.Key().String())
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return reflect.Value{}, "", nil
+	case 1:
+		return v.MapIndex(reflect.ValueOf(matches[0]).Convert(v.Type().Key())), matches[0], nil
+	default:
+		sort.Strings(matches)
+		return reflect.Value{}, "", fmt.Errorf("keys %s are ambiguous for %s", quoteAll(matches), name)
+	}
+}
+
+func quoteAll(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = strconv.Quote(key)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
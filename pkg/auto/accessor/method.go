@@ -0,0 +1,84 @@
+package accessor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+// Method returns an accessor for a method with no parameters that returns a value and optionally an error, such
+// as func (o *Order) Total() (Money, error). The method may come from the method set of a type or a pointer to
+// it, and is called on a pointer when its receiver is one.
+func Method(m reflect.Method) *MethodAccessor {
+	if !IsGetter(m) {
+		panic(fmt.Errorf("method %s must have no parameters and return a value and optionally an error, but got %v", m.Name, m.Type))
+	}
+
+	return &MethodAccessor{m}
+}
+
+// IsGetter reports whether the method m, taken from the method set of a type, can be used by a MethodAccessor.
+func IsGetter(m reflect.Method) bool {
+	t := m.Type
+	return m.IsExported() && t.NumIn() == 1 &&
+		(t.NumOut() == 1 || t.NumOut() == 2 && t.Out(1) == tError)
+}
+
+// MethodAccessor retrieves a value by calling a method.
+type MethodAccessor struct {
+	m reflect.Method
+}
+
+// Name implements the Accessor interface.
+func (a *MethodAccessor) Name() string {
+	return a.m.Name + "()"
+}
+
+// Type implements the Accessor interface.
+func (a *MethodAccessor) Type() reflect.Type {
+	return a.m.Type.Out(0)
+}
+
+// ValueFrom implements the Accessor interface. It panics when the method returns an error, which ValueFromErr
+// returns instead.
+func (a *MethodAccessor) ValueFrom(v reflect.Value) reflect.Value {
+	return mustValue(a.ValueFromErr(v))
+}
+
+// ValueFromErr implements the ErrAccessor interface. The error returned by the method, if any, is returned.
+func (a *MethodAccessor) ValueFromErr(v reflect.Value) (reflect.Value, error) {
+	if internal.IsNil(v) {
+		return v, nil
+	}
+
+	v = internal.UnwrapPtrValue(v)
+	if !v.IsValid() {
+		return v, nil
+	}
+
+	recv := a.m.Type.In(0)
+	if recv.Kind() == reflect.Ptr && v.Kind() != reflect.Ptr {
+		if v.CanAddr() {
+			v = v.Addr()
+		} else {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p
+		}
+	}
+
+	out := a.m.Func.Call([]reflect.Value{v})
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("calling %s: %w", a.Name(), out[1].Interface().(error))
+	}
+
+	return out[0], nil
+}
+
+// Method returns the method that is called.
+func (a *MethodAccessor) Method() reflect.Method {
+	return a.m
+}
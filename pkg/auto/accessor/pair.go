@@ -2,6 +2,9 @@ package accessor
 
 import (
 	"reflect"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
 )
 
 // Pair makes an PairAccessor to chain accessors together.
@@ -16,8 +19,21 @@ type PairAccessor struct {
 	second Accessor
 }
 
+// First returns the first accessor in the chain.
+func (a *PairAccessor) First() Accessor {
+	return a.first
+}
+
+// Second returns the second accessor in the chain.
+func (a *PairAccessor) Second() Accessor {
+	return a.second
+}
+
 // Name implements the Accessor interface.
 func (a *PairAccessor) Name() string {
+	if second := a.second.Name(); strings.HasPrefix(second, "[") {
+		return a.first.Name() + second
+	}
 	return a.first.Name() + "." + a.second.Name()
 }
 
@@ -26,12 +42,18 @@ func (a *PairAccessor) Type() reflect.Type {
 	return a.second.Type()
 }
 
-// ValueFrom implements the Accessor interface.
+// ValueFrom implements the Accessor interface. It panics when either accessor fails, for which ValueFromErr
+// returns an error instead.
 func (a *PairAccessor) ValueFrom(v reflect.Value) reflect.Value {
-	v = a.first.ValueFrom(v)
-	if v.IsNil() {
-		return v
+	return mustValue(a.ValueFromErr(v))
+}
+
+// ValueFromErr implements the ErrAccessor interface, returning the error from either accessor.
+func (a *PairAccessor) ValueFromErr(v reflect.Value) (reflect.Value, error) {
+	v, err := ValueFrom(a.first, v)
+	if err != nil || internal.IsNil(v) {
+		return v, err
 	}
 
-	return a.second.ValueFrom(v)
+	return ValueFrom(a.second, v)
 }
\ No newline at end of file
@@ -0,0 +1,291 @@
+package accessor
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/naming"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// Binder makes an accessor once the type of the source is known.
+type Binder interface {
+	// Bind returns an accessor for values of the type src, or an error when the accessor cannot read them. The
+	// fields of structs are found with lookup, such that fields renamed by tags are known by their new names,
+	// or by their exported names when lookup is nil.
+	Bind(src reflect.Type, lookup FieldLookup) (Accessor, error)
+}
+
+// FieldLookup returns the exported field of the struct t known by name, or an error when the fields of t cannot
+// be told apart, such as when one has a malformed tag.
+type FieldLookup func(t reflect.Type, name string) (reflect.StructField, bool, error)
+
+// Path returns a Binder for a path expression, such as Customer.Addresses[0].City, Attributes["color"] or
+// Lines[-1].SKU. Names are resolved to exported fields, getter methods or the keys of maps with string keys.
+// Indexes read the elements of slices and arrays, counting from the end when negative, and quoted or numeric
+// keys read the values of maps. A nil pointer, slice or map along the path results in the zero value. The path
+// is validated when it is bound to a source type.
+func Path(expr string, opts ...PathOption) Binder {
+	p := path{expr: expr}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
+// PathOption configures a path made by Path.
+type PathOption func(*path)
+
+// WithPathStrict returns an error when an index is out of range or a key is missing, rather than the zero
+// value.
+func WithPathStrict() PathOption {
+	return func(p *path) {
+		p.strict = true
+	}
+}
+
+// path binds a path expression to a chain of accessors.
+type path struct {
+	expr   string
+	strict bool
+}
+
+// Bind implements the Binder interface, returning a chain of accessors that follow the path through src.
+func (p *path) Bind(src reflect.Type, lookup FieldLookup) (Accessor, error) {
+	if lookup == nil {
+		lookup = exportedField
+	}
+
+	segments, err := parsePath(p.expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var acc Accessor
+	current := src
+	for _, seg := range segments {
+		next, err := seg.bind(internal.UnwrapPtrType(current), lookup, p.strict)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", p.expr, err)
+		}
+
+		if acc == nil {
+			acc = next
+		} else {
+			acc = Pair(acc, next)
+		}
+		current = next.Type()
+	}
+
+	return acc, nil
+}
+
+func exportedField(t reflect.Type, name string) (reflect.StructField, bool, error) {
+	fld, ok := t.FieldByName(name)
+	return fld, ok && fld.IsExported(), nil
+}
+
+// segment is a single step of a path expression: a name, an index or a quoted key.
+type segment struct {
+	name  string
+	index int
+	key   string
+	kind  segmentKind
+}
+
+type segmentKind int
+
+const (
+	nameSegment segmentKind = iota
+	indexSegment
+	keySegment
+)
+
+func parsePath(expr string) ([]segment, error) {
+	var segments []segment
+	rest := expr
+	for len(rest) > 0 {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q: missing ]", expr)
+			}
+			arg := rest[1:end]
+			if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') && arg[len(arg)-1] == arg[0] {
+				segments = append(segments, segment{key: arg[1 : len(arg)-1], kind: keySegment})
+			} else if i, err := strconv.Atoi(arg); err == nil {
+				segments = append(segments, segment{index: i, kind: indexSegment})
+			} else {
+				return nil, fmt.Errorf("path %q: invalid index %q", expr, arg)
+			}
+			rest = rest[end+1:]
+		case len(segments) > 0 && rest[0] != '.':
+			return nil, fmt.Errorf("path %q: expected . or [ at %q", expr, rest)
+		default:
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("path %q: missing name", expr)
+			}
+			segments = append(segments, segment{name: rest[:end], kind: nameSegment})
+			rest = rest[end:]
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path %q: empty", expr)
+	}
+
+	return segments, nil
+}
+
+func (s segment) bind(t reflect.Type, lookup FieldLookup, strict bool) (Accessor, error) {
+	switch s.kind {
+	case indexSegment:
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			return &indexAccessor{index: s.index, elem: t.Elem(), strict: strict}, nil
+		case reflect.Map:
+			if k := t.Key().Kind(); k < reflect.Int || k > reflect.Uint64 {
+				return nil, fmt.Errorf("cannot read key %d from %v", s.index, t)
+			}
+			return newKeyAccessor(t, strconv.Itoa(s.index), reflect.ValueOf(s.index), strict), nil
+		default:
+			return nil, fmt.Errorf("cannot index %v", t)
+		}
+	case keySegment:
+		if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot read key %q from %v", s.key, t)
+		}
+		return newKeyAccessor(t, strconv.Quote(s.key), reflect.ValueOf(s.key), strict), nil
+	default:
+		return bindName(t, lookup, s.name)
+	}
+}
+
+// bindName returns an accessor for the field found by lookup, getter method or map key known by name on t.
+func bindName(t reflect.Type, lookup FieldLookup, name string) (Accessor, error) {
+	switch t.Kind() {
+	case reflect.Struct:
+		fld, ok, err := lookup(t, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return Field(fld), nil
+		}
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return MapKey(naming.Exact(), name, t.Elem()), nil
+		}
+	case reflect.Interface:
+		// Only a map can be found in the interface once there is a value.
+		return MapKey(naming.Exact(), name, t), nil
+	}
+
+	if m, ok := reflect.PtrTo(t).MethodByName(name); ok && IsGetter(m) {
+		return Method(m), nil
+	}
+
+	return nil, fmt.Errorf("member %q does not exist on %v", name, t)
+}
+
+// indexAccessor retrieves an element of a slice or array.
+type indexAccessor struct {
+	index  int
+	elem   reflect.Type
+	strict bool
+}
+
+// Name implements the Accessor interface.
+func (a *indexAccessor) Name() string {
+	return fmt.Sprintf("[%d]", a.index)
+}
+
+// Type implements the Accessor interface.
+func (a *indexAccessor) Type() reflect.Type {
+	return a.elem
+}
+
+// ValueFrom implements the Accessor interface. It panics when a strict accessor finds nothing.
+func (a *indexAccessor) ValueFrom(v reflect.Value) reflect.Value {
+	return mustValue(a.ValueFromErr(v))
+}
+
+// ValueFromErr implements the ErrAccessor interface.
+func (a *indexAccessor) ValueFromErr(v reflect.Value) (reflect.Value, error) {
+	if internal.IsNil(v) {
+		return reflect.Value{}, nil
+	}
+
+	v = internal.UnwrapPtrValue(v)
+	if !v.IsValid() {
+		return v, nil
+	}
+
+	i := a.index
+	if i < 0 {
+		i += v.Len()
+	}
+	if i < 0 || i >= v.Len() {
+		if a.strict {
+			return reflect.Value{}, fmt.Errorf("index %d out of range with length %d", a.index, v.Len())
+		}
+		return reflect.Value{}, nil
+	}
+
+	return v.Index(i), nil
+}
+
+// keyAccessor retrieves the value of a map under a key.
+type keyAccessor struct {
+	name   string
+	key    reflect.Value
+	elem   reflect.Type
+	strict bool
+}
+
+// newKeyAccessor makes a keyAccessor for the map type t, where key is convertible to the key type of t.
+func newKeyAccessor(t reflect.Type, name string, key reflect.Value, strict bool) *keyAccessor {
+	return &keyAccessor{name: "[" + name + "]", key: key.Convert(t.Key()), elem: t.Elem(), strict: strict}
+}
+
+// Name implements the Accessor interface.
+func (a *keyAccessor) Name() string {
+	return a.name
+}
+
+// Type implements the Accessor interface.
+func (a *keyAccessor) Type() reflect.Type {
+	return a.elem
+}
+
+// ValueFrom implements the Accessor interface. It panics when a strict accessor finds nothing.
+func (a *keyAccessor) ValueFrom(v reflect.Value) reflect.Value {
+	return mustValue(a.ValueFromErr(v))
+}
+
+// ValueFromErr implements the ErrAccessor interface.
+func (a *keyAccessor) ValueFromErr(v reflect.Value) (reflect.Value, error) {
+	if internal.IsNil(v) {
+		return reflect.Value{}, nil
+	}
+
+	v = internal.UnwrapPtrValue(v)
+	if !v.IsValid() {
+		return v, nil
+	}
+
+	found := v.MapIndex(a.key)
+	if !found.IsValid() && a.strict {
+		return reflect.Value{}, fmt.Errorf("key %s not found", a.name[1:len(a.name)-1])
+	}
+
+	return found, nil
+}
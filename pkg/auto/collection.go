@@ -0,0 +1,95 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+func isList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+}
+
+// listFunc returns a function that maps a slice or array into a slice or array, element by element. A nil
+// src leaves the dst untouched, while an empty src produces an empty dst. An array dst must be large enough
+// to hold every element of the src; any remaining elements are left as zero values.
+func listFunc(cf converter.Factory, dst reflect.Type, src reflect.Type) (core.MapperFunc, error) {
+	elemFn, err := mapFuncFor(cf, dst.Elem(), src.Elem())
+	if err != nil {
+		return nil, fmt.Errorf("cannot map elements of %v from %v: %w", dst, src, err)
+	}
+
+	return func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		src = internal.UnwrapPtrValue(src)
+		dst = internal.EnsureSettableDst(dst)
+
+		n := src.Len()
+		var list reflect.Value
+		switch dst.Kind() {
+		case reflect.Array:
+			if n > dst.Len() {
+				return fmt.Errorf("cannot map %d elements into %v", n, dst.Type())
+			}
+			list = reflect.New(dst.Type()).Elem()
+		default:
+			list = reflect.MakeSlice(dst.Type(), n, n)
+		}
+
+		for i := 0; i < n; i++ {
+			if err := elemFn(ctx, list.Index(i).Addr(), src.Index(i)); err != nil {
+				return fmt.Errorf("mapping index %d: %w", i, err)
+			}
+		}
+
+		dst.Set(list)
+		return nil
+	}, nil
+}
+
+// mapFunc returns a function that maps a map into a map, mapping each key and value. A nil src leaves the
+// dst untouched, while an empty src produces an empty dst.
+func mapFunc(cf converter.Factory, dst reflect.Type, src reflect.Type) (core.MapperFunc, error) {
+	keyFn, err := mapFuncFor(cf, dst.Key(), src.Key())
+	if err != nil {
+		return nil, fmt.Errorf("cannot map keys of %v from %v: %w", dst, src, err)
+	}
+	elemFn, err := mapFuncFor(cf, dst.Elem(), src.Elem())
+	if err != nil {
+		return nil, fmt.Errorf("cannot map values of %v from %v: %w", dst, src, err)
+	}
+
+	return func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		src = internal.UnwrapPtrValue(src)
+		dst = internal.EnsureSettableDst(dst)
+
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(dst.Type().Key())
+			if err := keyFn(ctx, k, iter.Key()); err != nil {
+				return fmt.Errorf("mapping key %v: %w", iter.Key(), err)
+			}
+
+			v := reflect.New(dst.Type().Elem())
+			if err := elemFn(ctx, v, iter.Value()); err != nil {
+				return fmt.Errorf("mapping value for key %v: %w", iter.Key(), err)
+			}
+
+			m.SetMapIndex(k.Elem(), v.Elem())
+		}
+
+		dst.Set(m)
+		return nil
+	}, nil
+}
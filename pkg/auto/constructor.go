@@ -0,0 +1,129 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+// validateConstructor panics unless fn is a func returning a dst, or a pointer to one, and optionally an error,
+// with a name for each parameter when any are given.
+func validateConstructor(dst reflect.Type, fn reflect.Value, params []string) {
+	t := fn.Type()
+	if t.Kind() != reflect.Func || t.NumOut() < 1 || t.NumOut() > 2 ||
+		internal.UnwrapPtrType(t.Out(0)) != dst || (t.NumOut() == 2 && t.Out(1) != tError) {
+		panic(fmt.Errorf("constructor must be a func returning %v or *%v and optionally an error, but got %v", dst, dst, t))
+	}
+
+	if len(params) > 0 && len(params) != t.NumIn() {
+		panic(fmt.Errorf("constructor %v has %d parameters, but %d names were given", t, t.NumIn(), len(params)))
+	}
+}
+
+// constructor creates the destination by calling a function with arguments taken from the src.
+type constructor struct {
+	fn     reflect.Value
+	params []*param
+}
+
+// param maps a single argument of a constructor.
+type param struct {
+	accessor accessor.Accessor
+	mapFn    core.MapperFunc
+}
+
+// createConstructor matches each parameter of the constructor of s to a member of the src. Parameters are
+// matched by the names given with the constructor or, without names, to the exported fields of a src struct in
+// order. A src map has no order, so its keys can only be matched by name.
+func (p *Provider) createConstructor(s *Struct) (*constructor, error) {
+	if !s.constructor.IsValid() {
+		return nil, nil
+	}
+
+	ns := p.namingStrategyFor(s)
+	cf := p.converterFactoryFor(s)
+
+	t := s.constructor.Type()
+	names := s.constructorParams
+	if len(names) == 0 && t.NumIn() > 0 && s.src.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("constructor %v needs parameter names to map from %v, which has no fields in order",
+			t, typeName(s.src))
+	}
+	if len(names) == 0 {
+		for _, fld := range reflect.VisibleFields(s.src) {
+			if fld.PkgPath == "" && len(fld.Index) == 1 {
+				names = append(names, fld.Name)
+			}
+		}
+		if len(names) < t.NumIn() {
+			return nil, fmt.Errorf("constructor %v has %d parameters, but %v has %d exported fields",
+				t, t.NumIn(), s.src, len(names))
+		}
+	}
+
+	c := constructor{fn: s.constructor}
+	for i := 0; i < t.NumIn(); i++ {
+		acc, err := findAccessor(ns, p.getterPrefixes, names[i], s.src)
+		if err != nil {
+			return nil, fmt.Errorf("constructor parameter %d: %w", i, err)
+		}
+		if acc == nil && strings.ContainsAny(names[i], ".[") {
+			if acc, err = accessor.Path(names[i]).Bind(s.src, fieldByName); err != nil {
+				return nil, fmt.Errorf("constructor parameter %d: %w", i, err)
+			}
+		}
+		if acc == nil {
+			return nil, fmt.Errorf("constructor parameter %d: no member %q on %v", i, names[i], s.src)
+		}
+
+		mapFn, err := mapFuncFor(cf, t.In(i), acc.Type())
+		if err != nil {
+			return nil, fmt.Errorf("constructor parameter %d from %q: %w",
+				i, fmt.Sprintf("%v.%s", typeName(s.src), acc.Name()), err)
+		}
+
+		c.params = append(c.params, &param{accessor: acc, mapFn: mapFn})
+	}
+
+	return &c, nil
+}
+
+// construct calls the constructor with arguments from src and sets the settable dst to the result.
+func (c *constructor) construct(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+	t := c.fn.Type()
+	args := make([]reflect.Value, len(c.params))
+	for i, prm := range c.params {
+		arg := reflect.New(t.In(i))
+		v, err := accessor.ValueFrom(prm.accessor, src)
+		if err == nil {
+			err = prm.mapFn(ctx, arg, v)
+		}
+		if err != nil {
+			return fmt.Errorf("mapping constructor parameter %d from %q: %w",
+				i, fmt.Sprintf("%v.%s", internal.UnwrapPtrType(src.Type()), prm.accessor.Name()), err)
+		}
+		args[i] = arg.Elem()
+	}
+
+	out := c.fn.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return fmt.Errorf("constructing %v: %w", dst.Type(), out[1].Interface().(error))
+	}
+
+	v := out[0]
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fmt.Errorf("constructing %v: constructor returned nil", dst.Type())
+		}
+		v = v.Elem()
+	}
+
+	dst.Set(v)
+	return nil
+}
@@ -0,0 +1,250 @@
+package converter
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+type basicKind int
+
+const (
+	otherKind basicKind = iota
+	intKind
+	uintKind
+	floatKind
+	boolKind
+	stringKind
+)
+
+func basicKindOf(k reflect.Kind) basicKind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intKind
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintKind
+	case reflect.Float32, reflect.Float64:
+		return floatKind
+	case reflect.Bool:
+		return boolKind
+	case reflect.String:
+		return stringKind
+	default:
+		return otherKind
+	}
+}
+
+// basicConverter returns a converter between the numeric kinds, bool and string, or nil when either kind is
+// not one of those.
+func basicConverter(dst reflect.Kind, src reflect.Kind) Converter {
+	var fn func(dst reflect.Value, src reflect.Value) error
+	switch basicKindOf(dst) {
+	case intKind:
+		fn = toInt
+	case uintKind:
+		fn = toUint
+	case floatKind:
+		fn = toFloat
+	case boolKind:
+		fn = toBool
+	case stringKind:
+		fn = toString
+	}
+
+	if fn == nil || basicKindOf(src) == otherKind {
+		return nil
+	}
+
+	return valueFunc(fn)
+}
+
+func toInt(dst reflect.Value, src reflect.Value) error {
+	var i int64
+	switch basicKindOf(src.Kind()) {
+	case intKind:
+		i = src.Int()
+	case uintKind:
+		u := src.Uint()
+		if u > math.MaxInt64 {
+			return ErrOverflow
+		}
+		i = int64(u)
+	case floatKind:
+		f := src.Float()
+		if f != math.Trunc(f) {
+			return ErrTruncation
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return ErrOverflow
+		}
+		i = int64(f)
+	case boolKind:
+		if src.Bool() {
+			i = 1
+		}
+	case stringKind:
+		var err error
+		i, err = strconv.ParseInt(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return parseError(err)
+		}
+	}
+
+	if dst.OverflowInt(i) {
+		return ErrOverflow
+	}
+
+	dst.SetInt(i)
+	return nil
+}
+
+func toUint(dst reflect.Value, src reflect.Value) error {
+	var u uint64
+	switch basicKindOf(src.Kind()) {
+	case intKind:
+		i := src.Int()
+		if i < 0 {
+			return ErrSignLoss
+		}
+		u = uint64(i)
+	case uintKind:
+		u = src.Uint()
+	case floatKind:
+		f := src.Float()
+		if f < 0 {
+			return ErrSignLoss
+		}
+		if f != math.Trunc(f) {
+			return ErrTruncation
+		}
+		if f >= math.MaxUint64 {
+			return ErrOverflow
+		}
+		u = uint64(f)
+	case boolKind:
+		if src.Bool() {
+			u = 1
+		}
+	case stringKind:
+		s := src.String()
+		if len(s) > 0 && s[0] == '-' {
+			return ErrSignLoss
+		}
+
+		var err error
+		u, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return parseError(err)
+		}
+	}
+
+	if dst.OverflowUint(u) {
+		return ErrOverflow
+	}
+
+	dst.SetUint(u)
+	return nil
+}
+
+func toFloat(dst reflect.Value, src reflect.Value) error {
+	var f float64
+	switch basicKindOf(src.Kind()) {
+	case intKind:
+		f = float64(src.Int())
+	case uintKind:
+		f = float64(src.Uint())
+	case floatKind:
+		f = src.Float()
+	case boolKind:
+		if src.Bool() {
+			f = 1
+		}
+	case stringKind:
+		var err error
+		f, err = strconv.ParseFloat(src.String(), dst.Type().Bits())
+		if err != nil {
+			return parseError(err)
+		}
+	}
+
+	if !math.IsInf(f, 0) && dst.OverflowFloat(f) {
+		return ErrOverflow
+	}
+
+	dst.SetFloat(f)
+	return nil
+}
+
+func toBool(dst reflect.Value, src reflect.Value) error {
+	var b bool
+	switch basicKindOf(src.Kind()) {
+	case intKind:
+		switch src.Int() {
+		case 0:
+		case 1:
+			b = true
+		default:
+			return ErrOverflow
+		}
+	case uintKind:
+		switch src.Uint() {
+		case 0:
+		case 1:
+			b = true
+		default:
+			return ErrOverflow
+		}
+	case floatKind:
+		switch src.Float() {
+		case 0:
+		case 1:
+			b = true
+		default:
+			return ErrOverflow
+		}
+	case boolKind:
+		b = src.Bool()
+	case stringKind:
+		var err error
+		b, err = strconv.ParseBool(src.String())
+		if err != nil {
+			return parseError(err)
+		}
+	}
+
+	dst.SetBool(b)
+	return nil
+}
+
+func toString(dst reflect.Value, src reflect.Value) error {
+	var s string
+	switch basicKindOf(src.Kind()) {
+	case intKind:
+		s = strconv.FormatInt(src.Int(), 10)
+	case uintKind:
+		s = strconv.FormatUint(src.Uint(), 10)
+	case floatKind:
+		s = strconv.FormatFloat(src.Float(), 'g', -1, src.Type().Bits())
+	case boolKind:
+		s = strconv.FormatBool(src.Bool())
+	case stringKind:
+		s = src.String()
+	}
+
+	dst.SetString(s)
+	return nil
+}
+
+// parseError translates a range error from strconv into ErrOverflow.
+func parseError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// defaultIntermediates are the types, in addition to those registered, that a chain may pass through.
+var defaultIntermediates = []reflect.Type{
+	reflect.TypeOf(""),
+	reflect.TypeOf(int64(0)),
+	reflect.TypeOf(uint64(0)),
+	reflect.TypeOf(float64(0)),
+	reflect.TypeOf(false),
+	reflect.TypeOf([]byte(nil)),
+	reflect.TypeOf(time.Time{}),
+}
+
+// ChainConverter converts a value through a sequence of intermediate types.
+type ChainConverter struct {
+	path  []reflect.Type
+	steps []Converter
+}
+
+// Path returns the types the value passes through, beginning with the src type and ending with the dst type.
+func (c *ChainConverter) Path() []reflect.Type {
+	return append([]reflect.Type(nil), c.path...)
+}
+
+// String describes the path of the conversion.
+func (c *ChainConverter) String() string {
+	names := make([]string, len(c.path))
+	for i, t := range c.path {
+		names[i] = t.String()
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Convert implements the Converter interface.
+func (c *ChainConverter) Convert(dst reflect.Value, src reflect.Value) error {
+	if internal.IsNil(src) {
+		return nil
+	}
+
+	last := len(c.steps) - 1
+	for i, step := range c.steps[:last] {
+		next := reflect.New(c.path[i+1])
+		if err := convert(step, next, src); err != nil {
+			return fmt.Errorf("converting %v to %v: %w", c.path[i], c.path[i+1], err)
+		}
+		src = next.Elem()
+	}
+
+	if err := convert(c.steps[last], dst, src); err != nil {
+		return fmt.Errorf("converting %v to %v: %w", c.path[last], c.path[last+1], err)
+	}
+	return nil
+}
+
+// convert uses c to convert src into dst, assigning src directly when c is nil.
+func convert(c Converter, dst reflect.Value, src reflect.Value) error {
+	if c != nil {
+		return c.Convert(dst, src)
+	}
+
+	if internal.IsNil(src) {
+		return nil
+	}
+	internal.EnsureSettableDst(dst).Set(internal.UnwrapPtrValue(src))
+	return nil
+}
+
+// WithChaining enables composing up to maxSteps converters when no single converter supports a conversion. The
+// shortest chain through the registered types and a set of common types, such as string and int64, is used. A
+// maxSteps less than 2 disables chaining.
+func (r *Registry) WithChaining(maxSteps int) {
+	r.maxSteps = maxSteps
+	r.resetChains()
+}
+
+// Explain returns the types a value passes through when converted from src to dst, beginning with the src
+// type and ending with the dst type.
+func (r *Registry) Explain(dst reflect.Type, src reflect.Type) ([]reflect.Type, error) {
+	c, err := r.ConverterFor(dst, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if chain, ok := c.(*ChainConverter); ok {
+		return chain.Path(), nil
+	}
+
+	return []reflect.Type{internal.UnwrapPtrType(src), internal.UnwrapPtrType(dst)}, nil
+}
+
+// chainFor finds the shortest chain of converters from src to dst, caching the result.
+func (r *Registry) chainFor(dst reflect.Type, src reflect.Type) (*ChainConverter, bool) {
+	key := typePair{internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if chain, ok := r.chains[key]; ok {
+		return chain, chain != nil
+	}
+
+	chain := r.findChain(key.dst, key.src)
+	if r.chains == nil {
+		r.chains = make(map[typePair]*ChainConverter)
+	}
+	r.chains[key] = chain
+	return chain, chain != nil
+}
+
+// findChain performs a breadth first search from src through the intermediate types to find the shortest
+// chain to dst.
+func (r *Registry) findChain(dst reflect.Type, src reflect.Type) *ChainConverter {
+	candidates := r.intermediates()
+
+	type node struct {
+		t     reflect.Type
+		prev  *node
+		steps []Converter
+	}
+
+	visited := map[reflect.Type]bool{src: true, dst: true}
+	frontier := []*node{{t: src}}
+	for depth := 1; depth < r.maxSteps; depth++ {
+		var next []*node
+		for _, n := range frontier {
+			for _, t := range candidates {
+				if visited[t] {
+					continue
+				}
+
+				c, err := r.direct(t, n.t)
+				if err != nil {
+					continue
+				}
+
+				visited[t] = true
+				steps := append(n.steps[:len(n.steps):len(n.steps)], c)
+				reached := &node{t: t, prev: n, steps: steps}
+
+				last, err := r.direct(dst, t)
+				if err != nil {
+					next = append(next, reached)
+					continue
+				}
+
+				path := []reflect.Type{dst}
+				for p := reached; p != nil; p = p.prev {
+					path = append([]reflect.Type{p.t}, path...)
+				}
+				return &ChainConverter{
+					path:  path,
+					steps: append(steps, last),
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return nil
+}
+
+// intermediates returns the types a chain may pass through.
+func (r *Registry) intermediates() []reflect.Type {
+	seen := make(map[reflect.Type]bool)
+	var types []reflect.Type
+	add := func(t reflect.Type) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	for _, t := range defaultIntermediates {
+		add(t)
+	}
+	for _, key := range r.order {
+		add(key.src)
+		add(key.dst)
+	}
+
+	return types
+}
+
+func (r *Registry) resetChains() {
+	r.mu.Lock()
+	r.chains = nil
+	r.mu.Unlock()
+}
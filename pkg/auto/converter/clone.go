@@ -0,0 +1,132 @@
+package converter
+
+import (
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// DeepCopy makes a Factory that clones values whenever inner would assign them directly, so that the dst
+// shares no pointers, slices or maps with the src. Pointers, slices, maps, arrays, interfaces and the exported
+// fields of structs are copied recursively, and a value referenced more than once, including through a cycle,
+// is copied once. A type with a Clone method returning its own type, such as func (T) Clone() T, is copied by
+// calling it instead. Unexported struct fields, funcs and channels are shared.
+func DeepCopy(inner Factory) Factory {
+	return FactoryFunc(func(dst reflect.Type, src reflect.Type) (Converter, error) {
+		conv, err := inner.ConverterFor(dst, src)
+		if err != nil || conv != nil {
+			return conv, err
+		}
+
+		return Func(func(dst reflect.Value, src reflect.Value) error {
+			if internal.IsNil(src) {
+				return nil
+			}
+
+			c := cloner{visited: make(map[visit]reflect.Value)}
+			dst = internal.EnsureSettableDst(dst)
+			if src.Kind() == reflect.Ptr {
+				for src.Elem().Kind() == reflect.Ptr && !src.Elem().IsNil() {
+					src = src.Elem()
+				}
+				if src.Type().Elem() == dst.Type() {
+					// References back to the src value refer to the dst value.
+					c.visited[visit{src.Type(), src.Pointer()}] = dst.Addr()
+				}
+			}
+
+			if src = internal.UnwrapPtrValue(src); src.IsValid() {
+				dst.Set(c.clone(src))
+			}
+			return nil
+		}), nil
+	})
+}
+
+// visit identifies a pointer or map that has already been cloned.
+type visit struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+type cloner struct {
+	visited map[visit]reflect.Value
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	if internal.IsNil(v) {
+		return v
+	}
+
+	t := v.Type()
+	if method, ok := cloneMethod(t); ok {
+		return v.Method(method).Call(nil)[0]
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		key := visit{t, v.Pointer()}
+		if p, ok := c.visited[key]; ok {
+			return p
+		}
+
+		p := reflect.New(t.Elem())
+		c.visited[key] = p
+		p.Elem().Set(c.clone(v.Elem()))
+		return p
+	case reflect.Interface:
+		out := reflect.New(t).Elem()
+		out.Set(c.clone(v.Elem()))
+		return out
+	case reflect.Slice:
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		key := visit{t, v.Pointer()}
+		if m, ok := c.visited[key]; ok {
+			return m
+		}
+
+		out := reflect.MakeMapWithSize(t, v.Len())
+		c.visited[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" {
+				out.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// cloneMethod returns the index of the Clone method of t, when it has one returning t.
+func cloneMethod(t reflect.Type) (int, bool) {
+	if t.Kind() == reflect.Interface {
+		return 0, false
+	}
+
+	m, ok := t.MethodByName("Clone")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || m.Type.Out(0) != t {
+		return 0, false
+	}
+
+	return m.Index, true
+}
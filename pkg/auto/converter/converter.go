@@ -3,7 +3,6 @@ package converter
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 
 	"github.com/craiggwilson/go-mapper/pkg/internal"
 )
@@ -36,71 +35,67 @@ func (f Func) Convert(dst reflect.Value, src reflect.Value) error {
 	return f(dst, src)
 }
 
-// For is the default implementation of a Factory.
+// For is the default implementation of a Factory. It supports conversions between all the numeric kinds, bool
+// and string, returning a ConversionError when a value cannot be represented in the destination. Types that
+// implement encoding.TextMarshaler, encoding.TextUnmarshaler or fmt.Stringer are converted to and from string
+// and []byte using those methods. An integer type that implements fmt.Stringer and has an All method returning
+// every value, such as func (Status) All() []Status, is parsed from the names its String method returns.
+// Optional types, such as sql.NullString, sql.Null[T] and implementations of Optional, are converted through the
+// value they hold, with an empty optional clearing the dst.
 func For(dst reflect.Type, src reflect.Type) (Converter, error) {
 	dst = internal.UnwrapPtrType(dst)
 	src = internal.UnwrapPtrType(src)
-	if dst.AssignableTo(src) {
+	if src.AssignableTo(dst) {
 		return nil, nil
 	}
-	switch dst.Kind() {
-	case reflect.Int:
-		return toIntConverter(src)
-	case reflect.String:
-		return toStringConverter(src)
-	default:
-		return nil, fmt.Errorf("cannot convert from %v to %v", src, dst)
+
+	if conv, ok := defaultTime.converterFor(dst, src); ok {
+		return conv, nil
 	}
-}
 
-func toIntConverter(src reflect.Type) (Converter, error) {
-	switch src.Kind() {
-	case reflect.Int:
-		return nil, nil
-	case reflect.String:
-		return Func(stringToInt), nil
-	default:
-		return nil, fmt.Errorf("no converter for string -> %v available", src)
+	if conv, ok, err := optionalConverter(FactoryFunc(For), dst, src); ok {
+		return conv, err
 	}
-}
 
-func toStringConverter(src reflect.Type) (Converter, error) {
-	switch src.Kind() {
-	case reflect.Int:
-		return Func(intToString), nil
-	case reflect.String:
-		return nil, nil
-	default:
-		return nil, fmt.Errorf("no converter for int -> %v available", src)
+	if conv := textConverter(dst, src); conv != nil {
+		return conv, nil
 	}
-}
 
-func intToString(dst reflect.Value, src reflect.Value) error {
-	dst = internal.EnsureSettableDst(dst)
-	src = internal.UnwrapPtrValue(src)
+	if enum := detectEnum(dst); enum != nil {
+		if conv := enum.converterFor(dst, src); conv != nil {
+			return conv, nil
+		}
+	}
 
-	if src.IsZero() {
-		return nil
+	if conv := basicConverter(dst.Kind(), src.Kind()); conv != nil {
+		return conv, nil
 	}
 
-	s := strconv.FormatInt(src.Int(), 10)
-	dst.SetString(s)
-	return nil
+	return nil, unsupported(dst, src)
 }
 
-func stringToInt(dst reflect.Value, src reflect.Value) error {
-	dst = internal.EnsureSettableDst(dst)
-	src = internal.UnwrapPtrValue(src)
+func unsupported(dst reflect.Type, src reflect.Type) error {
+	return fmt.Errorf("cannot convert from %v to %v: %w", src, dst, ErrUnsupported)
+}
 
-	if src.IsZero() {
+// valueFunc returns a Converter that hands fn the bare src value and a settable dst, wrapping any error in a
+// ConversionError. A nil src leaves the dst untouched, as does an empty string unless the dst is a string.
+func valueFunc(fn func(dst reflect.Value, src reflect.Value) error) Converter {
+	return Func(func(dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		src = internal.UnwrapPtrValue(src)
+		if isText(src.Type()) && src.Len() == 0 && internal.UnwrapPtrType(dst.Type()).Kind() != reflect.String {
+			// An empty string has no value to parse.
+			return nil
+		}
+
+		dst = internal.EnsureSettableDst(dst)
+		if err := fn(dst, src); err != nil {
+			return conversionError(dst, src, err)
+		}
 		return nil
-	}
-
-	i, err := strconv.Atoi(src.String())
-	if err != nil {
-		return err
-	}
-
-	dst.SetInt(int64(i))
-	return nil
+	})
 }
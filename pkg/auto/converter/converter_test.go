@@ -1,8 +1,15 @@
 package converter_test
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	converter2 "github.com/craiggwilson/go-mapper/pkg/auto/converter"
 )
@@ -18,6 +25,29 @@ func TestConvert(t *testing.T) {
 		{"string to int", "10", 10},
 		{"string to *int", "10", ptrTo(10)},
 		{"string to **int", "10", ptrTo(ptrTo(10))},
+		{"string to int8", "-12", int8(-12)},
+		{"string to uint16", "65535", uint16(65535)},
+		{"string to float32", "1.5", float32(1.5)},
+		{"string to bool", "true", true},
+		{"empty string to int", "", 0},
+		{"int to string", 0, "0"},
+		{"int64 to int8", int64(-128), int8(-128)},
+		{"int to uint", 42, uint(42)},
+		{"uint64 to int", uint64(42), 42},
+		{"float64 to int32", float64(42), int32(42)},
+		{"float64 to float32", 1.5, float32(1.5)},
+		{"float32 to string", float32(0.1), "0.1"},
+		{"uint8 to float64", uint8(255), float64(255)},
+		{"bool to int", true, 1},
+		{"int to bool", 1, true},
+		{"bool to string", false, "false"},
+		{"named int to int", status(3), 3},
+		{"*int to int64", ptrTo(10), int64(10)},
+		{"TextMarshaler to string", currency{"USD"}, "usd"},
+		{"TextMarshaler to []byte", currency{"USD"}, []byte("usd")},
+		{"string to TextUnmarshaler", "usd", currency{"USD"}},
+		{"[]byte to *TextUnmarshaler", []byte("usd"), ptrTo(currency{"USD"})},
+		{"Stringer to string", orderID(42), "order-42"},
 	}
 
 	for _, tc := range testCases {
@@ -45,6 +75,628 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		src      any
+		dst      any
+		expected error
+	}{
+		{"int overflows int8", 128, int8(0), converter2.ErrOverflow},
+		{"uint64 overflows int64", uint64(math.MaxUint64), int64(0), converter2.ErrOverflow},
+		{"negative int to uint", -1, uint(0), converter2.ErrSignLoss},
+		{"negative float to uint8", -1.0, uint8(0), converter2.ErrSignLoss},
+		{"negative string to uint", "-1", uint(0), converter2.ErrSignLoss},
+		{"fractional float to int", 1.5, 0, converter2.ErrTruncation},
+		{"float64 overflows float32", math.MaxFloat64, float32(0), converter2.ErrOverflow},
+		{"float overflows int64", 1e19, int64(0), converter2.ErrOverflow},
+		{"string overflows int16", "40000", int16(0), converter2.ErrOverflow},
+		{"invalid string to int", "forty", 0, strconv.ErrSyntax},
+		{"int to bool", 2, false, converter2.ErrOverflow},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := reflect.ValueOf(tc.src)
+			dst := reflect.New(reflect.TypeOf(tc.dst))
+
+			converter, err := converter2.For(dst.Type(), src.Type())
+			if err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+
+			err = converter.Convert(dst, src)
+			var convErr *converter2.ConversionError
+			if !errors.As(err, &convErr) {
+				t.Fatalf("expected a ConversionError, but got %v", err)
+			}
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, but got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestConvertUnmarshalTextError(t *testing.T) {
+	t.Parallel()
+
+	c, err := converter2.For(reflect.TypeOf(currency{}), reflect.TypeOf(""))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	var dst currency
+	err = c.Convert(reflect.ValueOf(&dst), reflect.ValueOf("US"))
+	var convErr *converter2.ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("expected a ConversionError, but got %v", err)
+	}
+}
+
+func TestTimeConvert(t *testing.T) {
+	t.Parallel()
+
+	cst := time.FixedZone("CST", -6*60*60)
+	moment := time.Date(2021, 3, 4, 5, 6, 7, 8_000_000, cst)
+
+	testCases := []struct {
+		name     string
+		factory  converter2.Factory
+		src      any
+		expected any
+	}{
+		{"time to string", converter2.FactoryFunc(converter2.For), moment, "2021-03-04T05:06:07-06:00"},
+		{"string to time", converter2.FactoryFunc(converter2.For), "2021-03-04T11:06:07Z", time.Date(2021, 3, 4, 11, 6, 7, 0, time.UTC)},
+		{"time to int64", converter2.FactoryFunc(converter2.For), moment, moment.Unix()},
+		{"int64 to time", converter2.FactoryFunc(converter2.For), moment.Unix(), time.Unix(moment.Unix(), 0)},
+		{"time to timestamp", converter2.FactoryFunc(converter2.For), moment, timestamp{Seconds: moment.Unix(), Nanos: 8_000_000}},
+		{"timestamp to time", converter2.FactoryFunc(converter2.For), timestamp{Seconds: moment.Unix(), Nanos: 8_000_000}, time.Unix(moment.Unix(), 8_000_000)},
+		{"zero time to string", converter2.FactoryFunc(converter2.For), time.Time{}, ""},
+		{"duration to string", converter2.FactoryFunc(converter2.For), 90 * time.Second, "1m30s"},
+		{"string to duration", converter2.FactoryFunc(converter2.For), "1m30s", 90 * time.Second},
+		{"duration to int64", converter2.FactoryFunc(converter2.For), 90 * time.Second, int64(90 * time.Second)},
+		{"time to string with layout and location", converter2.Time(converter2.WithTimeLayout(time.RFC1123), converter2.WithTimeLocation(time.UTC)), moment, "Thu, 04 Mar 2021 11:06:07 UTC"},
+		{"time to time with location", converter2.Time(converter2.WithTimeLocation(time.UTC)), moment, moment.In(time.UTC)},
+		{"time to int64 with precision", converter2.Time(converter2.WithTimePrecision(time.Millisecond)), moment, moment.UnixMilli()},
+		{"int64 to time with precision", converter2.Time(converter2.WithTimePrecision(time.Millisecond), converter2.WithTimeLocation(time.UTC)), moment.UnixMilli(), time.UnixMilli(moment.UnixMilli()).In(time.UTC)},
+		{"duration to int with precision", converter2.Time(converter2.WithTimePrecision(time.Second)), 90 * time.Second, 90},
+		{"int to duration with precision", converter2.Time(converter2.WithTimePrecision(time.Second)), 90, 90 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := reflect.ValueOf(tc.src)
+			dst := reflect.New(reflect.TypeOf(tc.expected))
+
+			converter, err := tc.factory.ConverterFor(dst.Type(), src.Type())
+			if err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+
+			err = converter.Convert(dst, src)
+			if err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+
+			if !reflect.DeepEqual(tc.expected, dst.Elem().Interface()) {
+				t.Fatalf("expected %v, but got %v", tc.expected, dst.Elem().Interface())
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := converter2.Time().ConverterFor(reflect.TypeOf(new(int)), reflect.TypeOf("10"))
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+
+	t.Run("time overflows timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		type timestamp32 struct {
+			Seconds int32
+			Nanos   int32
+		}
+
+		c, err := converter2.For(reflect.TypeOf(new(timestamp32)), reflect.TypeOf(moment))
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+
+		var dst timestamp32
+		err = c.Convert(reflect.ValueOf(&dst), reflect.ValueOf(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)))
+		if !errors.Is(err, converter2.ErrOverflow) {
+			t.Fatalf("expected ErrOverflow, but got %v", err)
+		}
+		if dst != (timestamp32{}) {
+			t.Fatalf("expected dst to be untouched, but got %v", dst)
+		}
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := converter2.NewRegistry()
+	r.AddFactory(converter2.Time(converter2.WithTimeLayout(time.Kitchen)))
+	converter2.Register(r, func(dst *string, src int) error {
+		*dst = fmt.Sprintf("#%d", src)
+		return nil
+	})
+	r.Register(func(dst *currency, src orderID) error {
+		dst.code = "ORD"
+		return nil
+	})
+
+	convert := func(t *testing.T, dst any, src any) {
+		t.Helper()
+		c, err := r.ConverterFor(reflect.TypeOf(dst), reflect.TypeOf(src))
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if err = c.Convert(reflect.ValueOf(dst), reflect.ValueOf(src)); err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+	}
+
+	t.Run("generic registration overrides built-in", func(t *testing.T) {
+		var dst string
+		convert(t, &dst, 42)
+		if dst != "#42" {
+			t.Fatalf("expected #42, but got %v", dst)
+		}
+	})
+
+	t.Run("reflective registration", func(t *testing.T) {
+		var dst *currency
+		convert(t, &dst, orderID(1))
+		if dst == nil || dst.code != "ORD" {
+			t.Fatalf("expected ORD, but got %v", dst)
+		}
+	})
+
+	t.Run("factory", func(t *testing.T) {
+		var dst string
+		convert(t, &dst, time.Date(2021, 3, 4, 5, 6, 0, 0, time.UTC))
+		if dst != "5:06AM" {
+			t.Fatalf("expected 5:06AM, but got %v", dst)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		var dst int
+		convert(t, &dst, "42")
+		if dst != 42 {
+			t.Fatalf("expected 42, but got %v", dst)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := r.ConverterFor(reflect.TypeOf([]int{}), reflect.TypeOf(""))
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+}
+
+func TestRegistryChaining(t *testing.T) {
+	t.Parallel()
+
+	r := converter2.NewRegistry()
+	r.WithFallback(nil)
+	converter2.Register(r, func(dst *orderID, src currency) error {
+		*dst = orderID(len(src.code))
+		return nil
+	})
+	converter2.Register(r, func(dst *timestamp, src orderID) error {
+		dst.Seconds = int64(src)
+		return nil
+	})
+	converter2.Register(r, func(dst *status, src timestamp) error {
+		*dst = status(src.Seconds * 10)
+		return nil
+	})
+
+	currencyType := reflect.TypeOf(currency{})
+	timestampType := reflect.TypeOf(timestamp{})
+	statusType := reflect.TypeOf(status(0))
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := r.ConverterFor(timestampType, currencyType)
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+
+	r.WithChaining(2)
+
+	t.Run("shortest chain", func(t *testing.T) {
+		c, err := r.ConverterFor(timestampType, currencyType)
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+
+		var dst timestamp
+		if err = c.Convert(reflect.ValueOf(&dst), reflect.ValueOf(currency{code: "USD"})); err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if dst.Seconds != 3 {
+			t.Fatalf("expected 3, but got %v", dst.Seconds)
+		}
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		path, err := r.Explain(timestampType, reflect.PtrTo(currencyType))
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		expected := []reflect.Type{currencyType, reflect.TypeOf(orderID(0)), timestampType}
+		if !reflect.DeepEqual(path, expected) {
+			t.Fatalf("expected %v, but got %v", expected, path)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := r.ConverterFor(statusType, currencyType)
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+
+	t.Run("longer limit", func(t *testing.T) {
+		r.WithChaining(3)
+		c, err := r.ConverterFor(statusType, currencyType)
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if expected := "converter_test.currency -> converter_test.orderID -> converter_test.timestamp -> converter_test.status"; fmt.Sprint(c) != expected {
+			t.Fatalf("expected %q, but got %q", expected, fmt.Sprint(c))
+		}
+
+		var dst status
+		if err = c.Convert(reflect.ValueOf(&dst), reflect.ValueOf(currency{code: "EURO"})); err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if dst != 40 {
+			t.Fatalf("expected 40, but got %v", dst)
+		}
+	})
+}
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+
+	names := map[status]string{1: "active", 2: "inactive"}
+	convert := func(t *testing.T, f converter2.Factory, dst any, src any) error {
+		t.Helper()
+		c, err := f.ConverterFor(reflect.TypeOf(dst), reflect.TypeOf(src))
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		return c.Convert(reflect.ValueOf(dst), reflect.ValueOf(src))
+	}
+
+	testCases := []struct {
+		name     string
+		factory  converter2.Factory
+		src      any
+		expected any
+	}{
+		{"to name", converter2.Enum(names), status(1), "active"},
+		{"from name", converter2.Enum(names), "inactive", status(2)},
+		{"case insensitive", converter2.Enum(names, converter2.WithEnumCaseInsensitive()), "ACTIVE", status(1)},
+		{"unknown name to zero", converter2.Enum(names, converter2.WithEnumUnknownZero()), "deleted", status(0)},
+		{"unknown value to zero", converter2.Enum(names, converter2.WithEnumUnknownZero()), status(3), ""},
+		{"unknown name to default", converter2.Enum(names, converter2.WithEnumDefault(status(2))), "deleted", status(2)},
+		{"unknown value to default", converter2.Enum(names, converter2.WithEnumDefault(status(2))), status(3), "inactive"},
+		{"detected to name", converter2.FactoryFunc(converter2.For), colorBlue, "blue"},
+		{"detected from name", converter2.FactoryFunc(converter2.For), "blue", colorBlue},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dst := reflect.New(reflect.TypeOf(tc.expected))
+			if err := convert(t, tc.factory, dst.Interface(), tc.src); err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+			if actual := dst.Elem().Interface(); actual != tc.expected {
+				t.Fatalf("expected %v, but got %v", tc.expected, actual)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		var name string
+		if err := convert(t, converter2.Enum(names), &name, status(3)); !errors.Is(err, converter2.ErrUnknownEnum) {
+			t.Fatalf("expected ErrUnknownEnum, but got %v", err)
+		}
+
+		var c color
+		if err := convert(t, converter2.FactoryFunc(converter2.For), &c, "Red"); !errors.Is(err, converter2.ErrUnknownEnum) {
+			t.Fatalf("expected ErrUnknownEnum, but got %v", err)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := converter2.Enum(names).ConverterFor(reflect.TypeOf(new(int64)), reflect.TypeOf(status(3)))
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		r := converter2.NewRegistry()
+		r.AddFactory(converter2.Enum(names))
+		converter2.Register(r, func(dst *string, src int) error {
+			*dst = fmt.Sprintf("#%d", src)
+			return nil
+		})
+
+		var name string
+		if err := convert(t, r, &name, status(1)); err != nil || name != "active" {
+			t.Fatalf("expected active, but got %v (%v)", name, err)
+		}
+
+		var n int64
+		if err := convert(t, r, &n, status(3)); err != nil || n != 3 {
+			t.Fatalf("expected 3 from the registry's fallback, but got %v (%v)", n, err)
+		}
+
+		var num string
+		if err := convert(t, r, &num, 7); err != nil || num != "#7" {
+			t.Fatalf("expected #7 from the registered converter, but got %v (%v)", num, err)
+		}
+	})
+}
+
+func TestConvertOptional(t *testing.T) {
+	t.Parallel()
+
+	str := "hello"
+	num := int64(42)
+	when := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		dst      any
+		src      any
+		expected any
+	}{
+		{"NullString to *string", new(*string), sql.NullString{String: "hello", Valid: true}, &str},
+		{"invalid NullString to *string", ptrTo(&str), sql.NullString{String: "ignored"}, (*string)(nil)},
+		{"NullString to string", new(string), sql.NullString{String: "hello", Valid: true}, "hello"},
+		{"invalid NullString to string", ptrTo("stale"), sql.NullString{}, ""},
+		{"*string to NullString", new(sql.NullString), &str, sql.NullString{String: "hello", Valid: true}},
+		{"nil *string to NullString", new(sql.NullString), (*string)(nil), sql.NullString{}},
+		{"NullInt64 to *int64", new(*int64), sql.NullInt64{Int64: 42, Valid: true}, &num},
+		{"NullInt64 to string", new(string), sql.NullInt64{Int64: 42, Valid: true}, "42"},
+		{"string to NullInt64", new(sql.NullInt64), "42", sql.NullInt64{Int64: 42, Valid: true}},
+		{"NullBool to bool", new(bool), sql.NullBool{Bool: true, Valid: true}, true},
+		{"NullTime to string", new(string), sql.NullTime{Time: when, Valid: true}, "2021-03-04T05:06:07Z"},
+		{"NullInt32 to NullInt64", new(sql.NullInt64), sql.NullInt32{Int32: 42, Valid: true}, sql.NullInt64{Int64: 42, Valid: true}},
+		{"Null[int] to *int64", new(*int64), sql.Null[int]{V: 42, Valid: true}, &num},
+		{"int64 to Null[string]", new(sql.Null[string]), int64(42), sql.Null[string]{V: "42", Valid: true}},
+		{"option to string", new(string), option[int]{value: 42, set: true}, "42"},
+		{"empty option to *string", ptrTo(&str), option[int]{}, (*string)(nil)},
+		{"string to option", new(option[int]), "42", option[int]{value: 42, set: true}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := converter2.For(reflect.TypeOf(tc.dst), reflect.TypeOf(tc.src))
+			if err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+			if err = c.Convert(reflect.ValueOf(tc.dst), reflect.ValueOf(tc.src)); err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+			if actual := reflect.ValueOf(tc.dst).Elem().Interface(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, but got %v", tc.expected, actual)
+			}
+		})
+	}
+
+	t.Run("struct with Valid field", func(t *testing.T) {
+		type verdict struct {
+			Valid  bool
+			Reason string
+		}
+
+		_, err := converter2.For(reflect.TypeOf(new(verdict)), reflect.TypeOf("reason"))
+		if !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported, but got %v", err)
+		}
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		type money struct {
+			cents int64
+		}
+
+		r := converter2.NewRegistry()
+		converter2.Register(r, func(dst *money, src int64) error {
+			*dst = money{cents: src}
+			return nil
+		})
+		converter2.Register(r, func(dst *int64, src money) error {
+			*dst = src.cents
+			return nil
+		})
+		convert := func(t *testing.T, dst any, src any) error {
+			t.Helper()
+			c, err := r.ConverterFor(reflect.TypeOf(dst), reflect.TypeOf(src))
+			if err != nil {
+				t.Fatalf("expected no error, but got %v", err)
+			}
+			return c.Convert(reflect.ValueOf(dst), reflect.ValueOf(src))
+		}
+
+		var m money
+		if err := convert(t, &m, sql.NullInt64{Int64: 42, Valid: true}); err != nil || m.cents != 42 {
+			t.Fatalf("expected 42 cents, but got %v (%v)", m, err)
+		}
+
+		var n sql.NullInt64
+		if err := convert(t, &n, money{cents: 7}); err != nil || n != (sql.NullInt64{Int64: 7, Valid: true}) {
+			t.Fatalf("expected 7, but got %v (%v)", n, err)
+		}
+
+		if _, err := converter2.For(reflect.TypeOf(&m), reflect.TypeOf(sql.NullInt64{})); !errors.Is(err, converter2.ErrUnsupported) {
+			t.Fatalf("expected ErrUnsupported from For, but got %v", err)
+		}
+	})
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Name     string
+		Tags     []string
+		Attrs    map[string]*int
+		Next     *node
+		Any      any
+		Snapshot snapshot
+	}
+
+	one := 1
+	src := &node{
+		Name:     "a",
+		Tags:     []string{"x", "y"},
+		Attrs:    map[string]*int{"one": &one},
+		Any:      []int{1},
+		Snapshot: snapshot{data: []int{1}},
+	}
+	src.Next = src
+
+	f := converter2.DeepCopy(converter2.FactoryFunc(converter2.For))
+	c, err := f.ConverterFor(reflect.TypeOf(&node{}), reflect.TypeOf(src))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	var dst *node
+	if err = c.Convert(reflect.ValueOf(&dst), reflect.ValueOf(src)); err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	if dst == src || dst.Next != dst {
+		t.Fatalf("expected the cycle to be copied")
+	}
+	if dst.Name != "a" || !reflect.DeepEqual(dst.Tags, src.Tags) || *dst.Attrs["one"] != 1 {
+		t.Fatalf("expected %v, but got %v", src, dst)
+	}
+
+	dst.Tags[0] = "z"
+	*dst.Attrs["one"] = 2
+	dst.Any.([]int)[0] = 2
+	if src.Tags[0] != "x" || one != 1 || src.Any.([]int)[0] != 1 {
+		t.Fatalf("expected the src to be unchanged, but got %v", src)
+	}
+
+	if !dst.Snapshot.cloned || src.Snapshot.cloned {
+		t.Fatalf("expected the Clone method to be used")
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := converter2.For(reflect.TypeOf([]int{}), reflect.TypeOf(""))
+	if err == nil {
+		t.Fatalf("expected an error, but got none")
+	}
+}
+
+type status int
+
+type timestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+type currency struct {
+	code string
+}
+
+func (c currency) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(c.code)), nil
+}
+
+func (c *currency) UnmarshalText(text []byte) error {
+	if len(text) != 3 {
+		return fmt.Errorf("invalid currency %q", text)
+	}
+	c.code = strings.ToUpper(string(text))
+	return nil
+}
+
+type orderID int
+
+func (id orderID) String() string {
+	return fmt.Sprintf("order-%d", int(id))
+}
+
+type color int
+
+const (
+	colorRed color = iota
+	colorBlue
+)
+
+func (c color) All() []color {
+	return []color{colorRed, colorBlue}
+}
+
+func (c color) String() string {
+	switch c {
+	case colorRed:
+		return "red"
+	case colorBlue:
+		return "blue"
+	default:
+		return fmt.Sprintf("color(%d)", int(c))
+	}
+}
+
+type option[T any] struct {
+	value T
+	set   bool
+}
+
+func (o *option[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+func (o *option[T]) Set(v T) {
+	o.value = v
+	o.set = true
+}
+
+var _ converter2.Optional[int] = (*option[int])(nil)
+
+type snapshot struct {
+	data   []int
+	cloned bool
+}
+
+func (s snapshot) Clone() snapshot {
+	return snapshot{data: append([]int(nil), s.data...), cloned: true}
+}
+
 func ptrTo(i interface{}) interface{} {
 	v := reflect.ValueOf(i)
 	p := reflect.New(v.Type())
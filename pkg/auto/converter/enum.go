@@ -0,0 +1,203 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// Enum makes an EnumFactory, which converts the values of E to and from the names given to them. Other
+// conversions are unsupported, so that it can be added to a Registry.
+func Enum[E comparable](names map[E]string, opts ...EnumOption) *EnumFactory {
+	var o enumOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var def reflect.Value
+	if o.def != nil {
+		v, ok := o.def.(E)
+		if !ok {
+			panic(fmt.Errorf("default enum value must be a %v but got %T", reflect.TypeOf((*E)(nil)).Elem(), o.def))
+		}
+		def = reflect.ValueOf(v)
+	}
+
+	values := make([]reflect.Value, 0, len(names))
+	strs := make([]string, 0, len(names))
+	for v, name := range names {
+		values = append(values, reflect.ValueOf(v))
+		strs = append(strs, name)
+	}
+
+	return &EnumFactory{
+		table: newEnumTable(reflect.TypeOf((*E)(nil)).Elem(), values, strs, o.caseInsensitive, o.unknown, def),
+	}
+}
+
+// EnumOption configures an EnumFactory.
+type EnumOption func(*enumOptions)
+
+type enumOptions struct {
+	caseInsensitive bool
+	unknown         unknownPolicy
+	def             any
+}
+
+// WithEnumCaseInsensitive matches names without regard to case.
+func WithEnumCaseInsensitive() EnumOption {
+	return func(o *enumOptions) {
+		o.caseInsensitive = true
+	}
+}
+
+// WithEnumDefault uses v in place of an unknown name, and the name of v in place of an unknown value. It must
+// be of the same type as the enum.
+func WithEnumDefault[E comparable](v E) EnumOption {
+	return func(o *enumOptions) {
+		o.unknown = unknownDefault
+		o.def = v
+	}
+}
+
+// WithEnumUnknownZero uses the zero value in place of an unknown name, and an empty string in place of an
+// unknown value.
+func WithEnumUnknownZero() EnumOption {
+	return func(o *enumOptions) {
+		o.unknown = unknownZero
+	}
+}
+
+// EnumFactory is a Factory that converts the values of an enum to and from their names. By default, a name or
+// value that is not part of the enum is an error wrapping ErrUnknownEnum.
+type EnumFactory struct {
+	table *enumTable
+}
+
+// ConverterFor implements the Factory interface.
+func (f *EnumFactory) ConverterFor(dst reflect.Type, src reflect.Type) (Converter, error) {
+	if conv := f.table.converterFor(internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src)); conv != nil {
+		return conv, nil
+	}
+
+	return nil, unsupported(dst, src)
+}
+
+type unknownPolicy int
+
+const (
+	unknownError unknownPolicy = iota
+	unknownZero
+	unknownDefault
+)
+
+// enumTable holds the names of the values of an enum type.
+type enumTable struct {
+	typ             reflect.Type
+	names           map[any]string
+	values          map[string]reflect.Value
+	caseInsensitive bool
+	unknown         unknownPolicy
+	def             reflect.Value
+}
+
+func newEnumTable(
+	typ reflect.Type,
+	values []reflect.Value,
+	names []string,
+	caseInsensitive bool,
+	unknown unknownPolicy,
+	def reflect.Value,
+) *enumTable {
+	t := enumTable{
+		typ:             typ,
+		names:           make(map[any]string, len(values)),
+		values:          make(map[string]reflect.Value, len(values)),
+		caseInsensitive: caseInsensitive,
+		unknown:         unknown,
+		def:             def,
+	}
+
+	for i, v := range values {
+		t.names[v.Interface()] = names[i]
+		t.values[t.key(names[i])] = v
+	}
+
+	return &t
+}
+
+// detectEnum returns an enumTable for t when it is an integer type that implements fmt.Stringer and has an
+// All method returning every value of t.
+func detectEnum(t reflect.Type) *enumTable {
+	if basicKindOf(t.Kind()) != intKind && basicKindOf(t.Kind()) != uintKind || !t.Implements(tStringer) {
+		return nil
+	}
+
+	all, ok := t.MethodByName("All")
+	if !ok || all.Type.NumIn() != 1 || all.Type.NumOut() != 1 || all.Type.Out(0) != reflect.SliceOf(t) {
+		return nil
+	}
+
+	list := all.Func.Call([]reflect.Value{reflect.Zero(t)})[0]
+	values := make([]reflect.Value, list.Len())
+	names := make([]string, list.Len())
+	for i := range values {
+		values[i] = list.Index(i)
+		names[i] = values[i].Interface().(fmt.Stringer).String()
+	}
+
+	return newEnumTable(t, values, names, false, unknownError, reflect.Value{})
+}
+
+func (t *enumTable) converterFor(dst reflect.Type, src reflect.Type) Converter {
+	switch {
+	case src == t.typ && dst.Kind() == reflect.String:
+		return valueFunc(t.toName)
+	case dst == t.typ && src.Kind() == reflect.String:
+		return valueFunc(t.fromName)
+	default:
+		return nil
+	}
+}
+
+func (t *enumTable) key(name string) string {
+	if t.caseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+func (t *enumTable) toName(dst reflect.Value, src reflect.Value) error {
+	name, ok := t.names[src.Interface()]
+	if !ok {
+		switch t.unknown {
+		case unknownZero:
+		case unknownDefault:
+			name = t.names[t.def.Interface()]
+		default:
+			return ErrUnknownEnum
+		}
+	}
+
+	dst.SetString(name)
+	return nil
+}
+
+func (t *enumTable) fromName(dst reflect.Value, src reflect.Value) error {
+	v, ok := t.values[t.key(src.String())]
+	if !ok {
+		switch t.unknown {
+		case unknownZero:
+			v = reflect.Zero(t.typ)
+		case unknownDefault:
+			v = t.def
+		default:
+			return ErrUnknownEnum
+		}
+	}
+
+	dst.Set(v.Convert(dst.Type()))
+	return nil
+}
@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrUnsupported indicates a Factory does not support converting between the types.
+	ErrUnsupported = errors.New("unsupported conversion")
+	// ErrOverflow indicates the value is out of the range of the destination type.
+	ErrOverflow = errors.New("value out of range")
+	// ErrSignLoss indicates a negative value cannot be represented by an unsigned destination type.
+	ErrSignLoss = errors.New("negative value for unsigned type")
+	// ErrTruncation indicates the fractional part of a value would be lost.
+	ErrTruncation = errors.New("fractional part would be lost")
+	// ErrUnknownEnum indicates a value or name is not part of an enum.
+	ErrUnknownEnum = errors.New("unknown enum value")
+)
+
+// isUnsupported reports whether err is, or wraps, ErrUnsupported.
+func isUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported)
+}
+
+// ConversionError is returned when a value cannot be converted.
+type ConversionError struct {
+	// Value is the value being converted.
+	Value any
+	// Dst is the type the value was being converted into.
+	Dst reflect.Type
+	// Err is the reason for the failure.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v (%T) to %v: %v", e.Value, e.Value, e.Dst, e.Err)
+}
+
+// Unwrap returns the reason for the failure.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func conversionError(dst reflect.Value, src reflect.Value, err error) error {
+	return &ConversionError{
+		Value: src.Interface(),
+		Dst:   dst.Type(),
+		Err:   err,
+	}
+}
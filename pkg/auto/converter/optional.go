@@ -0,0 +1,168 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var tBool = reflect.TypeOf(false)
+
+// Optional is implemented by pointers to types that may or may not hold a value of T, where the zero value
+// holds none. For converts to and from such types using their methods, in the same way it converts
+// database/sql types such as sql.NullString and sql.Null[T].
+type Optional[T any] interface {
+	// Get returns the value and whether there is one.
+	Get() (T, bool)
+	// Set stores the value.
+	Set(v T)
+}
+
+// optional describes how to get and set the value held by an optional type.
+type optional struct {
+	elem reflect.Type
+	get  func(v reflect.Value) (reflect.Value, bool)
+	set  func(v reflect.Value, elem reflect.Value)
+}
+
+// optionalOf returns how to access the value held by t when t is an optional type. The database/sql Null types,
+// such as sql.NullString and sql.Null[T], are optional, as is a type implementing Optional.
+func optionalOf(t reflect.Type) (*optional, bool) {
+	if opt, ok := nullOptional(t); ok {
+		return opt, true
+	}
+	return methodOptional(t)
+}
+
+// nullOptional describes the database/sql Null types, which hold a value in one field and whether it is valid in
+// the Valid field. Other structs shaped the same way are left alone, as a Valid field may mean anything.
+func nullOptional(t reflect.Type) (*optional, bool) {
+	if t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") ||
+		t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return nil, false
+	}
+
+	valid, ok := t.FieldByName("Valid")
+	if !ok || valid.Type != tBool || valid.PkgPath != "" {
+		return nil, false
+	}
+
+	value := t.Field(0)
+	if value.Index[0] == valid.Index[0] {
+		value = t.Field(1)
+	}
+	if value.PkgPath != "" {
+		return nil, false
+	}
+
+	return &optional{
+		elem: value.Type,
+		get: func(v reflect.Value) (reflect.Value, bool) {
+			return v.FieldByIndex(value.Index), v.FieldByIndex(valid.Index).Bool()
+		},
+		set: func(v reflect.Value, elem reflect.Value) {
+			v.FieldByIndex(value.Index).Set(elem)
+			v.FieldByIndex(valid.Index).SetBool(true)
+		},
+	}, true
+}
+
+func methodOptional(t reflect.Type) (*optional, bool) {
+	if t.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	pt := reflect.PtrTo(t)
+	get, ok := pt.MethodByName("Get")
+	if !ok || get.Type.NumIn() != 1 || get.Type.NumOut() != 2 || get.Type.Out(1) != tBool {
+		return nil, false
+	}
+
+	elem := get.Type.Out(0)
+	set, ok := pt.MethodByName("Set")
+	if !ok || set.Type.NumIn() != 2 || set.Type.NumOut() != 0 || set.Type.In(1) != elem {
+		return nil, false
+	}
+
+	return &optional{
+		elem: elem,
+		get: func(v reflect.Value) (reflect.Value, bool) {
+			out := addr(v).Method(get.Index).Call(nil)
+			return out[0], out[1].Bool()
+		},
+		set: func(v reflect.Value, e reflect.Value) {
+			addr(v).Method(set.Index).Call([]reflect.Value{e})
+		},
+	}, true
+}
+
+// optionalConverter returns a converter to or from an optional type, reporting false when neither is optional.
+// The value held by the optional is converted with a converter from f. An empty optional src clears the dst, so
+// that a pointer dst is nil, and a nil src leaves an optional dst empty.
+func optionalConverter(f Factory, dst reflect.Type, src reflect.Type) (Converter, bool, error) {
+	if opt, ok := optionalOf(src); ok {
+		conv, err := f.ConverterFor(dst, opt.elem)
+		if err != nil {
+			return nil, true, err
+		}
+
+		return Func(func(dst reflect.Value, src reflect.Value) error {
+			if internal.IsNil(src) {
+				return nil
+			}
+
+			v, ok := opt.get(internal.UnwrapPtrValue(src))
+			if !ok {
+				clearDst(dst)
+				return nil
+			}
+			return convert(conv, dst, v)
+		}), true, nil
+	}
+
+	if opt, ok := optionalOf(dst); ok {
+		conv, err := f.ConverterFor(opt.elem, src)
+		if err != nil {
+			return nil, true, err
+		}
+
+		return Func(func(dst reflect.Value, src reflect.Value) error {
+			if internal.IsNil(src) {
+				return nil
+			}
+
+			v := reflect.New(opt.elem)
+			if err := convert(conv, v, src); err != nil {
+				return err
+			}
+
+			dst = internal.EnsureSettableDst(dst)
+			dst.Set(reflect.Zero(dst.Type()))
+			opt.set(dst, v.Elem())
+			return nil
+		}), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// addr returns a pointer to v, copying v when it cannot be addressed.
+func addr(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// clearDst sets the value dst points to back to its zero value.
+func clearDst(dst reflect.Value) {
+	if dst.IsNil() {
+		return
+	}
+	dst = dst.Elem()
+	dst.Set(reflect.Zero(dst.Type()))
+}
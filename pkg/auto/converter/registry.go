@@ -0,0 +1,165 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var tErr = reflect.TypeOf((*error)(nil)).Elem()
+
+// NewRegistry makes a Registry that falls back to For.
+func NewRegistry() *Registry {
+	return &Registry{
+		pairs:    make(map[typePair]Converter),
+		fallback: FactoryFunc(For),
+	}
+}
+
+// Registry is a Factory composed of converters registered for exact types and a list of factories. A
+// converter registered for the exact dst and src types is preferred, followed by the factories, from the most
+// recently added to the first, then optional types, whose values are converted by the registry, and finally the
+// fallback. A factory that returns an error wrapping ErrUnsupported defers to the next one, so factories such as
+// those made by Time and Enum only handle the conversions they are for.
+type Registry struct {
+	pairs     map[typePair]Converter
+	order     []typePair
+	factories []Factory
+	fallback  Factory
+
+	maxSteps int
+	mu       sync.Mutex
+	chains   map[typePair]*ChainConverter
+}
+
+// AddFactory adds a factory to the registry, taking precedence over those previously added.
+func (r *Registry) AddFactory(f Factory) {
+	r.factories = append(r.factories, f)
+	r.resetChains()
+}
+
+// WithFallback sets the factory used when nothing else in the registry supports a conversion. A nil fallback
+// supports nothing.
+func (r *Registry) WithFallback(f Factory) {
+	r.fallback = f
+	r.resetChains()
+}
+
+// RegisterConverter registers c for the exact dst and src types. Pointers are removed from both types, so c must
+// handle the same values as those from For.
+func (r *Registry) RegisterConverter(dst reflect.Type, src reflect.Type, c Converter) {
+	key := typePair{internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src)}
+	if _, ok := r.pairs[key]; !ok {
+		r.order = append(r.order, key)
+	}
+	r.pairs[key] = c
+	r.resetChains()
+}
+
+// Register registers fn as the converter between its argument types. The fn argument must match the signature
+// func(dst *<type>, src <type>) error. If fn is not a function, or its signature does not match the
+// requirements, a panic is raised.
+func (r *Registry) Register(fn any) {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		panic(fmt.Errorf("fn argument must be a func but got %v", t))
+	}
+	if t.NumIn() != 2 || t.In(0).Kind() != reflect.Ptr {
+		panic(fmt.Errorf("fn function must have 2 arguments, the first being a pointer, but was %v", t))
+	}
+	if t.NumOut() != 1 || t.Out(0) != tErr {
+		panic(fmt.Errorf("fn function must return an error, but was %v", t))
+	}
+
+	v := reflect.ValueOf(fn)
+	dst := t.In(0).Elem()
+	src := t.In(1)
+	r.RegisterConverter(dst, src, registeredFunc(dst, src, func(dst reflect.Value, src reflect.Value) error {
+		result := v.Call([]reflect.Value{dst, src})
+		if result[0].IsNil() {
+			return nil
+		}
+		return result[0].Interface().(error)
+	}))
+}
+
+// Register registers fn with r as the converter from Src to Dst.
+func Register[Dst, Src any](r *Registry, fn func(dst *Dst, src Src) error) {
+	dst := reflect.TypeOf((*Dst)(nil)).Elem()
+	src := reflect.TypeOf((*Src)(nil)).Elem()
+	r.RegisterConverter(dst, src, registeredFunc(dst, src, func(dst reflect.Value, src reflect.Value) error {
+		return fn(dst.Interface().(*Dst), src.Interface().(Src))
+	}))
+}
+
+// ConverterFor implements the Factory interface. When chaining is enabled and no single converter supports the
+// conversion, a ChainConverter is returned.
+func (r *Registry) ConverterFor(dst reflect.Type, src reflect.Type) (Converter, error) {
+	c, err := r.direct(dst, src)
+	if err == nil || !isUnsupported(err) || r.maxSteps < 2 {
+		return c, err
+	}
+
+	if chain, ok := r.chainFor(dst, src); ok {
+		return chain, nil
+	}
+
+	return nil, err
+}
+
+// direct returns a single converter from src to dst.
+func (r *Registry) direct(dst reflect.Type, src reflect.Type) (Converter, error) {
+	if c, ok := r.pairs[typePair{internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src)}]; ok {
+		return c, nil
+	}
+
+	for i := len(r.factories) - 1; i >= 0; i-- {
+		c, err := r.factories[i].ConverterFor(dst, src)
+		if err == nil {
+			return c, nil
+		}
+		if !isUnsupported(err) {
+			return nil, err
+		}
+	}
+
+	if d, s := internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src); !s.AssignableTo(d) {
+		if c, ok, err := optionalConverter(r, d, s); ok {
+			return c, err
+		}
+	}
+
+	if r.fallback == nil {
+		return nil, unsupported(dst, src)
+	}
+
+	return r.fallback.ConverterFor(dst, src)
+}
+
+type typePair struct {
+	dst reflect.Type
+	src reflect.Type
+}
+
+// registeredFunc adapts fn, which takes a *dst and a src, to handle the values of any pointer depth that a
+// Converter is handed.
+func registeredFunc(dst reflect.Type, src reflect.Type, fn func(dst reflect.Value, src reflect.Value) error) Converter {
+	return Func(func(d reflect.Value, s reflect.Value) error {
+		if internal.IsNil(s) {
+			return nil
+		}
+
+		for s.Type() != src && s.Kind() == reflect.Ptr {
+			s = s.Elem()
+		}
+		if s.Type() != src && reflect.PtrTo(s.Type()) == src {
+			p := reflect.New(s.Type())
+			p.Elem().Set(s)
+			s = p
+		}
+
+		return fn(internal.EnsureSettableDst(d).Addr(), s)
+	})
+}
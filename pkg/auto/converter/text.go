@@ -0,0 +1,91 @@
+package converter
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	tStringer        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	tTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	tTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isText reports whether t is a string or a []byte.
+func isText(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// implements reports whether t, or a pointer to t, implements iface.
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// textConverter returns a converter that uses encoding.TextMarshaler, encoding.TextUnmarshaler or fmt.Stringer
+// to convert to and from text, or nil when none apply.
+func textConverter(dst reflect.Type, src reflect.Type) Converter {
+	switch {
+	case isText(dst) && implements(src, tTextMarshaler):
+		return valueFunc(marshalText)
+	case dst.Kind() == reflect.String && implements(src, tStringer):
+		return valueFunc(stringify)
+	case isText(src) && implements(dst, tTextUnmarshaler):
+		return valueFunc(unmarshalText)
+	default:
+		return nil
+	}
+}
+
+func marshalText(dst reflect.Value, src reflect.Value) error {
+	text, err := methodReceiver(src, tTextMarshaler).Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return err
+	}
+
+	setText(dst, text)
+	return nil
+}
+
+func stringify(dst reflect.Value, src reflect.Value) error {
+	s := methodReceiver(src, tStringer).Interface().(fmt.Stringer).String()
+	dst.SetString(s)
+	return nil
+}
+
+func unmarshalText(dst reflect.Value, src reflect.Value) error {
+	var text []byte
+	if src.Kind() == reflect.String {
+		text = []byte(src.String())
+	} else {
+		text = src.Bytes()
+	}
+
+	if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+		return fmt.Errorf("UnmarshalText failed: %w", err)
+	}
+	return nil
+}
+
+// methodReceiver returns v, or a pointer to a copy of v, such that it implements iface.
+func methodReceiver(v reflect.Value, iface reflect.Type) reflect.Value {
+	if v.Type().Implements(iface) {
+		return v
+	}
+	if v.CanAddr() {
+		return v.Addr()
+	}
+
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+func setText(dst reflect.Value, text []byte) {
+	if dst.Kind() == reflect.String {
+		dst.SetString(string(text))
+		return
+	}
+
+	dst.SetBytes(append([]byte(nil), text...))
+}
@@ -0,0 +1,274 @@
+package converter
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var (
+	tDuration = reflect.TypeOf(time.Duration(0))
+	tTime     = reflect.TypeOf(time.Time{})
+)
+
+// defaultTime handles the time conversions for For.
+var defaultTime = &TimeFactory{layout: time.RFC3339}
+
+// Time makes a TimeFactory, which converts time.Time and time.Duration values. Other conversions are
+// unsupported, so that it can be added to a Registry to change how times are converted.
+func Time(opts ...TimeOption) *TimeFactory {
+	f := TimeFactory{
+		layout: time.RFC3339,
+	}
+
+	for _, opt := range opts {
+		opt(&f)
+	}
+
+	return &f
+}
+
+// TimeOption configures a TimeFactory.
+type TimeOption func(*TimeFactory)
+
+// WithTimeLayout sets the layout used to format and parse strings. The default is time.RFC3339.
+func WithTimeLayout(layout string) TimeOption {
+	return func(f *TimeFactory) {
+		f.layout = layout
+	}
+}
+
+// WithTimeLocation normalizes every time.Time produced into loc, such as time.UTC. Strings without a time zone
+// are parsed in loc.
+func WithTimeLocation(loc *time.Location) TimeOption {
+	return func(f *TimeFactory) {
+		f.location = loc
+	}
+}
+
+// WithTimePrecision truncates every time.Time produced to precision, which is also the unit of integers
+// converted to and from time.Time and time.Duration. By default, integers are Unix seconds for time.Time and
+// nanoseconds for time.Duration.
+func WithTimePrecision(precision time.Duration) TimeOption {
+	return func(f *TimeFactory) {
+		f.precision = precision
+	}
+}
+
+// TimeFactory is a Factory that converts time.Time to and from string, integers holding a Unix timestamp and
+// structs with Seconds and Nanos fields, as well as time.Duration to and from string and integers.
+type TimeFactory struct {
+	layout    string
+	location  *time.Location
+	precision time.Duration
+}
+
+// ConverterFor implements the Factory interface.
+func (f *TimeFactory) ConverterFor(dst reflect.Type, src reflect.Type) (Converter, error) {
+	if conv, ok := f.converterFor(internal.UnwrapPtrType(dst), internal.UnwrapPtrType(src)); ok {
+		return conv, nil
+	}
+
+	return nil, unsupported(dst, src)
+}
+
+func (f *TimeFactory) converterFor(dst reflect.Type, src reflect.Type) (Converter, bool) {
+	switch {
+	case dst == tTime && src == tTime:
+		if f.location == nil && f.precision == 0 {
+			return nil, false
+		}
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			dst.Set(reflect.ValueOf(f.normalize(src.Interface().(time.Time))))
+			return nil
+		}), true
+	case src == tTime:
+		return f.fromTime(dst)
+	case dst == tTime:
+		return f.toTime(src)
+	case src == tDuration:
+		return f.fromDuration(dst)
+	case dst == tDuration:
+		return f.toDuration(src)
+	default:
+		return nil, false
+	}
+}
+
+func (f *TimeFactory) fromTime(dst reflect.Type) (Converter, bool) {
+	switch {
+	case dst.Kind() == reflect.String:
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			dst.SetString(f.normalize(src.Interface().(time.Time)).Format(f.layout))
+			return nil
+		}), true
+	case basicKindOf(dst.Kind()) == intKind:
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			i := f.toUnix(f.normalize(src.Interface().(time.Time)))
+			if dst.OverflowInt(i) {
+				return ErrOverflow
+			}
+			dst.SetInt(i)
+			return nil
+		}), true
+	case isTimestamp(dst):
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			t := f.normalize(src.Interface().(time.Time))
+			seconds, nanos := dst.FieldByName("Seconds"), dst.FieldByName("Nanos")
+			if seconds.OverflowInt(t.Unix()) || nanos.OverflowInt(int64(t.Nanosecond())) {
+				return ErrOverflow
+			}
+			seconds.SetInt(t.Unix())
+			nanos.SetInt(int64(t.Nanosecond()))
+			return nil
+		}), true
+	default:
+		return nil, false
+	}
+}
+
+func (f *TimeFactory) toTime(src reflect.Type) (Converter, bool) {
+	switch {
+	case src.Kind() == reflect.String:
+		return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+			loc := f.location
+			if loc == nil {
+				loc = time.UTC
+			}
+			t, err := time.ParseInLocation(f.layout, src.String(), loc)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(f.normalize(t)))
+			return nil
+		}), true
+	case basicKindOf(src.Kind()) == intKind:
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			dst.Set(reflect.ValueOf(f.normalize(f.fromUnix(src.Int()))))
+			return nil
+		}), true
+	case isTimestamp(src):
+		return timeFunc(func(dst reflect.Value, src reflect.Value) error {
+			t := time.Unix(src.FieldByName("Seconds").Int(), src.FieldByName("Nanos").Int())
+			dst.Set(reflect.ValueOf(f.normalize(t)))
+			return nil
+		}), true
+	default:
+		return nil, false
+	}
+}
+
+func (f *TimeFactory) fromDuration(dst reflect.Type) (Converter, bool) {
+	switch {
+	case dst.Kind() == reflect.String:
+		return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+			dst.SetString(time.Duration(src.Int()).String())
+			return nil
+		}), true
+	case basicKindOf(dst.Kind()) == intKind:
+		return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+			i := src.Int()
+			if f.precision > 0 {
+				i /= int64(f.precision)
+			}
+			if dst.OverflowInt(i) {
+				return ErrOverflow
+			}
+			dst.SetInt(i)
+			return nil
+		}), true
+	default:
+		return nil, false
+	}
+}
+
+func (f *TimeFactory) toDuration(src reflect.Type) (Converter, bool) {
+	switch {
+	case src.Kind() == reflect.String:
+		return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+			d, err := time.ParseDuration(src.String())
+			if err != nil {
+				return err
+			}
+			dst.SetInt(int64(d))
+			return nil
+		}), true
+	case basicKindOf(src.Kind()) == intKind:
+		return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+			i := src.Int()
+			if f.precision > 0 {
+				d := i * int64(f.precision)
+				if d/int64(f.precision) != i {
+					return ErrOverflow
+				}
+				i = d
+			}
+			dst.SetInt(i)
+			return nil
+		}), true
+	default:
+		return nil, false
+	}
+}
+
+func (f *TimeFactory) normalize(t time.Time) time.Time {
+	if f.location != nil {
+		t = t.In(f.location)
+	}
+	if f.precision > 0 {
+		t = t.Truncate(f.precision)
+	}
+	return t
+}
+
+func (f *TimeFactory) toUnix(t time.Time) int64 {
+	switch f.precision {
+	case 0, time.Second:
+		return t.Unix()
+	case time.Millisecond:
+		return t.UnixMilli()
+	case time.Microsecond:
+		return t.UnixMicro()
+	default:
+		return t.UnixNano() / int64(f.precision)
+	}
+}
+
+func (f *TimeFactory) fromUnix(i int64) time.Time {
+	switch f.precision {
+	case 0, time.Second:
+		return time.Unix(i, 0)
+	case time.Millisecond:
+		return time.UnixMilli(i)
+	case time.Microsecond:
+		return time.UnixMicro(i)
+	default:
+		return time.Unix(0, i*int64(f.precision))
+	}
+}
+
+// isTimestamp reports whether t is a struct with integer Seconds and Nanos fields.
+func isTimestamp(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	seconds, ok := t.FieldByName("Seconds")
+	if !ok || basicKindOf(seconds.Type.Kind()) != intKind {
+		return false
+	}
+	nanos, ok := t.FieldByName("Nanos")
+	return ok && basicKindOf(nanos.Type.Kind()) == intKind
+}
+
+// timeFunc returns a Converter like valueFunc, except that a zero time.Time or a zero src leaves the dst
+// untouched.
+func timeFunc(fn func(dst reflect.Value, src reflect.Value) error) Converter {
+	return valueFunc(func(dst reflect.Value, src reflect.Value) error {
+		if src.IsZero() {
+			return nil
+		}
+		return fn(dst, src)
+	})
+}
@@ -0,0 +1,30 @@
+package auto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnmappedFieldsError reports the destination fields that have no accessor, no custom mapper and are not
+// ignored.
+type UnmappedFieldsError struct {
+	// Fields are the paths to the unmapped fields, in the form <type>.<field>.
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e *UnmappedFieldsError) Error() string {
+	return fmt.Sprintf("unmapped destination fields: %s", strings.Join(e.Fields, ", "))
+}
+
+// IrreversibleFieldsError reports the destination fields of a reversed mapping whose mapping could not be
+// reversed.
+type IrreversibleFieldsError struct {
+	// Fields are the paths to the irreversible fields, in the form <type>.<field>.
+	Fields []string
+}
+
+// Error implements the error interface.
+func (e *IrreversibleFieldsError) Error() string {
+	return fmt.Sprintf("irreversible destination fields: %s", strings.Join(e.Fields, ", "))
+}
@@ -0,0 +1,14 @@
+package auto
+
+import (
+	"reflect"
+)
+
+// Register adds Dst and Src to p to automatically create a core.Mapper.
+func Register[Dst, Src any](p *Provider, opts ...func(structOpts)) {
+	p.Add(
+		reflect.TypeOf((*Dst)(nil)).Elem(),
+		reflect.TypeOf((*Src)(nil)).Elem(),
+		opts...,
+	)
+}
@@ -0,0 +1,279 @@
+package auto
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
+	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/auto/mutator"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+var (
+	tTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	tTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isStringMap reports whether t is a map with string keys.
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isNestedStruct reports whether t is a struct that is mapped to and from a map field by field, rather than
+// converted as a whole like a time.Time.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.Implements(tTextMarshaler) || reflect.PtrTo(t).Implements(tTextUnmarshaler) {
+		return false
+	}
+
+	for _, fld := range reflect.VisibleFields(t) {
+		if fld.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// withNestedMaps adds to structs the mappings needed for the structs nested within those mapped to or from a
+// map with string keys, such that mapping an order from a map also maps its customer from the nested map. The
+// reverse of a mapping to or from a map is added as a separate mapping.
+func withNestedMaps(structs []*Struct) []*Struct {
+	type pair struct {
+		dst reflect.Type
+		src reflect.Type
+	}
+	structs = append([]*Struct(nil), structs...)
+	seen := make(map[pair]bool, len(structs))
+	for _, s := range structs {
+		seen[pair{s.dst, s.src}] = true
+	}
+
+	for i := 0; i < len(structs); i++ {
+		s := structs[i]
+		add := func(dst reflect.Type, src reflect.Type) {
+			if seen[pair{dst, src}] {
+				return
+			}
+			seen[pair{dst, src}] = true
+			structs = append(structs, &Struct{
+				dst:              dst,
+				src:              src,
+				converterFactory: s.converterFactory,
+				deepCopy:         s.deepCopy,
+				flattenEmbedded:  s.flattenEmbedded,
+				namingStrategy:   s.namingStrategy,
+			})
+		}
+
+		if s.reverse && (isStringMap(s.src) || isStringMap(s.dst)) {
+			// The reverse of a mapping with a map is a mapping of its own.
+			forward := *s
+			forward.reverse = false
+			structs[i] = &forward
+			add(s.src, s.dst)
+		}
+
+		switch {
+		case isStringMap(s.src) && s.dst.Kind() == reflect.Struct:
+			walkNested(s.dst, func(t reflect.Type) { add(t, s.src) })
+		case isStringMap(s.dst) && s.src.Kind() == reflect.Struct:
+			walkNested(s.src, func(t reflect.Type) { add(s.dst, t) })
+		}
+	}
+
+	return structs
+}
+
+// walkNested calls fn with each nested struct type reachable through the exported fields of the struct t,
+// including through pointers and the elements of collections.
+func walkNested(t reflect.Type, fn func(reflect.Type)) {
+	visited := map[reflect.Type]bool{t: true}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for _, fld := range reflect.VisibleFields(t) {
+			if !fld.IsExported() || len(fld.Index) != 1 {
+				continue
+			}
+
+			bare := fld.Type
+			for bare.Kind() == reflect.Ptr || bare.Kind() == reflect.Slice || bare.Kind() == reflect.Array ||
+				bare.Kind() == reflect.Map {
+				bare = bare.Elem()
+			}
+			if visited[bare] || !isNestedStruct(bare) {
+				continue
+			}
+
+			visited[bare] = true
+			fn(bare)
+			walk(bare)
+		}
+	}
+
+	walk(t)
+}
+
+// createMapMembers creates the members that set each exported field of the src of s as a key in the map dst
+// of s. The key is the name of the field, or the name given by its tag, and fields holding nil are left out.
+// When the map holds interfaces, nested structs become nested maps, including those in slices, arrays and maps.
+func (p *Provider) createMapMembers(s *Struct) ([]*member, error) {
+	cf := p.converterFactoryFor(s)
+	elem := s.dst.Elem()
+
+	var members []*member
+	for i := 0; i < s.src.NumField(); i++ {
+		fld := s.src.Field(i)
+		if fld.PkgPath != "" {
+			continue
+		}
+
+		t, err := parseTag(fld)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %v: %w", typeName(s.src), err)
+		}
+		if t.ignore {
+			continue
+		}
+
+		mapFn, ok := nestedValueFunc(s.dst, fld.Type)
+		if !ok {
+			if mapFn, err = mapFuncFor(cf, elem, fld.Type); err != nil {
+				return nil, fmt.Errorf("mapping key %q from %q: %w",
+					t.memberName(fld), fmt.Sprintf("%v.%s", typeName(s.src), fld.Name), err)
+			}
+		}
+
+		members = append(members, &member{
+			mutator:   mutator.MapKey(t.memberName(fld), elem),
+			accessor:  accessor.Field(fld),
+			mapFn:     mapFn,
+			omitEmpty: t.omitEmpty,
+		})
+	}
+
+	return members, nil
+}
+
+// nestedValueFunc returns a function that maps a value of type t for a map of type mapType holding interfaces,
+// such that nested structs become nested maps of mapType, and collections of them become []interface{} or maps
+// of mapType. It reports false when mapType does not hold interfaces or t holds no nested structs.
+func nestedValueFunc(mapType reflect.Type, t reflect.Type) (core.MapperFunc, bool) {
+	if mapType.Elem().Kind() != reflect.Interface {
+		return nil, false
+	}
+
+	bare := internal.UnwrapPtrType(t)
+	switch bare.Kind() {
+	case reflect.Struct:
+		if !isNestedStruct(bare) {
+			return nil, false
+		}
+		return nestedMapFunc(mapType), true
+	case reflect.Slice, reflect.Array:
+		elemFn, ok := nestedValueFunc(mapType, bare.Elem())
+		if !ok {
+			return nil, false
+		}
+		return nestedCollectionFunc(reflect.SliceOf(mapType.Elem()), elemFn), true
+	case reflect.Map:
+		if bare.Key().Kind() != reflect.String {
+			return nil, false
+		}
+		elemFn, ok := nestedValueFunc(mapType, bare.Elem())
+		if !ok {
+			return nil, false
+		}
+		return nestedCollectionFunc(mapType, elemFn), true
+	default:
+		return nil, false
+	}
+}
+
+// nestedCollectionFunc returns a function that maps a slice, array or map with string keys into a new value of
+// type collType, either a slice or a map, mapping each element with elemFn.
+func nestedCollectionFunc(collType reflect.Type, elemFn core.MapperFunc) core.MapperFunc {
+	return func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+		src = internal.UnwrapPtrValue(src)
+
+		var coll reflect.Value
+		if collType.Kind() == reflect.Map {
+			coll = reflect.MakeMapWithSize(collType, src.Len())
+			iter := src.MapRange()
+			for iter.Next() {
+				v := reflect.New(collType.Elem())
+				if err := elemFn(ctx, v, iter.Value()); err != nil {
+					return fmt.Errorf("mapping value for key %v: %w", iter.Key(), err)
+				}
+				coll.SetMapIndex(iter.Key().Convert(collType.Key()), v.Elem())
+			}
+		} else {
+			coll = reflect.MakeSlice(collType, src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := elemFn(ctx, coll.Index(i).Addr(), src.Index(i)); err != nil {
+					return fmt.Errorf("mapping index %d: %w", i, err)
+				}
+			}
+		}
+
+		internal.EnsureSettableDst(dst).Set(coll)
+		return nil
+	}
+}
+
+// nestedMapFunc returns a function that maps a struct into a new map of type mapType using the mapper
+// registered with the core.Context, and sets it on the dst.
+func nestedMapFunc(mapType reflect.Type) core.MapperFunc {
+	return func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		m := reflect.New(mapType)
+		if err := mapNested(ctx, m, src); err != nil {
+			return err
+		}
+
+		internal.EnsureSettableDst(dst).Set(m.Elem())
+		return nil
+	}
+}
+
+// dynamicFunc returns a function that maps the value held by an interface into a pointer to a value of type
+// dst, choosing how to do so from the type of the value.
+func dynamicFunc(cf converter.Factory, dst reflect.Type) core.MapperFunc {
+	var cache sync.Map
+	return func(ctx core.Context, d reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+		for src.Kind() == reflect.Interface {
+			src = src.Elem()
+		}
+
+		fn, ok := cache.Load(src.Type())
+		if !ok {
+			mapFn, err := mapFuncFor(cf, dst, src.Type())
+			if err != nil {
+				return err
+			}
+			fn, _ = cache.LoadOrStore(src.Type(), mapFn)
+		}
+
+		return fn.(core.MapperFunc)(ctx, d, src)
+	}
+}
+
+// typeName returns the name of t, or its description when it has no name.
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
@@ -0,0 +1,172 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
+	"github.com/craiggwilson/go-mapper/pkg/auto/mutator"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// member maps a single destination member, found by following a path of struct fields from the destination.
+// When there is a mutator, the path leads to the struct the mutator is applied to.
+type member struct {
+	path    []reflect.StructField
+	mutator mutator.Mutator
+	// accessor retrieves the value handed to mapFn from the src. When nil, mapFn is handed the entire src.
+	accessor accessor.Accessor
+	mapFn    core.MapperFunc
+	// customConverter records that mapFn uses a converter given for the member, which cannot be reversed.
+	customConverter bool
+	// omitEmpty leaves the destination untouched when the value from the accessor is empty.
+	omitEmpty bool
+}
+
+func (m *member) name() string {
+	if m.mutator == nil {
+		return pathName(m.path)
+	}
+	if len(m.path) == 0 {
+		return m.mutator.Name()
+	}
+	return pathName(m.path) + "." + m.mutator.Name()
+}
+
+// srcPath returns the path in src read by the member, naming the keys leading to the value when reading a map.
+func (m *member) srcPath(src reflect.Value) string {
+	if a, ok := m.accessor.(*accessor.MapKeyAccessor); ok {
+		return fmt.Sprintf("%v%s", src.Type(), a.KeyPath(src))
+	}
+	return fmt.Sprintf("%v.%s", src.Type(), m.srcName())
+}
+
+// dstType returns the type of the value set by the member.
+func (m *member) dstType() reflect.Type {
+	if m.mutator != nil {
+		return m.mutator.Type()
+	}
+	return m.path[len(m.path)-1].Type
+}
+
+func (m *member) srcName() string {
+	if m.accessor == nil {
+		return "(custom function)"
+	}
+	return m.accessor.Name()
+}
+
+// allocates reports whether setting the member in the struct dst would allocate a nil intermediate or embedded
+// pointer along its path.
+func (m *member) allocates(dst reflect.Value) bool {
+	v := dst
+	ok := true
+	for i, fld := range m.path {
+		for j, x := range fld.Index {
+			if j > 0 {
+				if v, ok = deref(v); !ok {
+					return true
+				}
+			}
+			v = v.Field(x)
+		}
+		if i < len(m.path)-1 {
+			if v, ok = deref(v); !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deref follows the pointers from v, reporting false when one of them is nil.
+func deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// mapInto maps the member into the settable struct dst. Intermediate and embedded pointers along the path are
+// only allocated when there is a value to set, such that an empty value leaves a nil pointer nil but still
+// overwrites a field that can be reached.
+func (m *member) mapInto(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+	if m.accessor != nil {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		var err error
+		if src, err = accessor.ValueFrom(m.accessor, src); err != nil {
+			return err
+		}
+		if (internal.IsNil(src) || src.IsZero()) && (m.omitEmpty || m.allocates(dst)) {
+			return nil
+		}
+	}
+
+	if m.mutator != nil {
+		return m.mutate(ctx, dst, src)
+	}
+
+	last := len(m.path) - 1
+	for _, fld := range m.path[:last] {
+		dst = internal.EnsureSettableDst(internal.FieldByIndex(dst, fld.Index).Addr())
+	}
+
+	fv := internal.FieldByIndex(dst, m.path[last].Index)
+	if !fv.CanAddr() {
+		return fmt.Errorf("field %q cannot be addressed", m.name())
+	}
+
+	return m.mapFn(ctx, fv.Addr(), src)
+}
+
+// mutate maps src into a new value that is handed to the mutator. A nil src leaves the destination untouched.
+func (m *member) mutate(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+	if internal.IsNil(src) {
+		return nil
+	}
+
+	for _, fld := range m.path {
+		dst = internal.EnsureSettableDst(internal.FieldByIndex(dst, fld.Index).Addr())
+	}
+
+	v := reflect.New(m.mutator.Type())
+	if err := m.mapFn(ctx, v, src); err != nil {
+		return err
+	}
+
+	return m.mutator.SetValue(dst, v.Elem())
+}
+
+// newStructMapper makes a core.Mapper that creates the destination with ctor, when not nil, and then maps each
+// of the members.
+func newStructMapper(dst reflect.Type, src reflect.Type, ctor *constructor, members []*member) core.Mapper {
+	return core.NewFunctionMapper(
+		dst,
+		src,
+		func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+			dst = internal.EnsureSettableDst(dst)
+			if ctor != nil {
+				if err := ctor.construct(ctx, dst, src); err != nil {
+					return err
+				}
+			}
+			for _, m := range members {
+				if err := m.mapInto(ctx, dst, src); err != nil {
+					return fmt.Errorf("mapping field %q from %q: %w",
+						fmt.Sprintf("%v.%s", dst.Type(), m.name()),
+						m.srcPath(src),
+						err)
+				}
+			}
+
+			return nil
+		},
+	)
+}
@@ -0,0 +1,37 @@
+package mutator
+
+import (
+	"reflect"
+)
+
+// MapKey returns a mutator that sets the value under key in a map with string keys, where elem is the element
+// type of the map. A nil map is allocated.
+func MapKey(key string, elem reflect.Type) *MapKeyMutator {
+	return &MapKeyMutator{key: key, elem: elem}
+}
+
+// MapKeyMutator sets a value in a map with string keys.
+type MapKeyMutator struct {
+	key  string
+	elem reflect.Type
+}
+
+// Name implements the Mutator interface.
+func (m *MapKeyMutator) Name() string {
+	return m.key
+}
+
+// Type implements the Mutator interface.
+func (m *MapKeyMutator) Type() reflect.Type {
+	return m.elem
+}
+
+// SetValue implements the Mutator interface.
+func (m *MapKeyMutator) SetValue(dst reflect.Value, v reflect.Value) error {
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+
+	dst.SetMapIndex(reflect.ValueOf(m.key).Convert(dst.Type().Key()), v)
+	return nil
+}
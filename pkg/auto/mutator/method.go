@@ -0,0 +1,88 @@
+package mutator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var tError = reflect.TypeOf((*error)(nil)).Elem()
+
+// Method returns a mutator for a setter method with a single parameter, taken from the method set of a pointer
+// to the type it sets. The method may return nothing, an error, the type or a pointer to it, or the type or a
+// pointer to it and an error. A returned value replaces the destination, so that methods returning an updated
+// copy, such as func (o Order) WithTotal(total Money) Order, can be used on immutable types.
+func Method(m reflect.Method) *MethodMutator {
+	if !IsSetter(m) {
+		panic(fmt.Errorf("method %s must have a single parameter and return nothing, an error or its receiver type, but got %v", m.Name, m.Type))
+	}
+
+	return &MethodMutator{m}
+}
+
+// IsSetter reports whether the method m, taken from the method set of a pointer to a type, can be used by a
+// MethodMutator.
+func IsSetter(m reflect.Method) bool {
+	t := m.Type
+	if !m.IsExported() || t.NumIn() != 2 || t.In(0).Kind() != reflect.Ptr {
+		return false
+	}
+
+	self := func(out reflect.Type) bool {
+		return out == t.In(0) || out == t.In(0).Elem()
+	}
+
+	switch t.NumOut() {
+	case 0:
+		return true
+	case 1:
+		return t.Out(0) == tError || self(t.Out(0))
+	case 2:
+		return self(t.Out(0)) && t.Out(1) == tError
+	default:
+		return false
+	}
+}
+
+// MethodMutator sets a value by calling a method.
+type MethodMutator struct {
+	m reflect.Method
+}
+
+// Name implements the Mutator interface.
+func (m *MethodMutator) Name() string {
+	return m.m.Name + "()"
+}
+
+// Type implements the Mutator interface.
+func (m *MethodMutator) Type() reflect.Type {
+	return m.m.Type.In(1)
+}
+
+// SetValue implements the Mutator interface. The error returned by the method, if any, is returned.
+func (m *MethodMutator) SetValue(dst reflect.Value, v reflect.Value) error {
+	out := m.m.Func.Call([]reflect.Value{dst.Addr(), v})
+	if n := len(out); n > 0 && out[n-1].Type() == tError {
+		if !out[n-1].IsNil() {
+			return fmt.Errorf("calling %s: %w", m.Name(), out[n-1].Interface().(error))
+		}
+		out = out[:n-1]
+	}
+
+	if len(out) == 1 {
+		result := out[0]
+		if result.Kind() == reflect.Ptr {
+			if result.IsNil() {
+				return fmt.Errorf("calling %s: returned nil", m.Name())
+			}
+			result = result.Elem()
+		}
+		dst.Set(result)
+	}
+
+	return nil
+}
+
+// Method returns the method that is called.
+func (m *MethodMutator) Method() reflect.Method {
+	return m.m
+}
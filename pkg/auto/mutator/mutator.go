@@ -0,0 +1,15 @@
+package mutator
+
+import (
+	"reflect"
+)
+
+// Mutator sets a value.
+type Mutator interface {
+	// Name indicates the destination of the value.
+	Name() string
+	// Type is the type of the value that is set.
+	Type() reflect.Type
+	// SetValue sets the value v on the settable dst.
+	SetValue(dst reflect.Value, v reflect.Value) error
+}
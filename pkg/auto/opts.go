@@ -11,6 +11,14 @@ type withAccessorOpt interface {
 	WithAccessor(accessor.Accessor)
 }
 
+type withBinderOpt interface {
+	WithBinder(accessor.Binder)
+}
+
+type withConstructorOpt interface {
+	WithConstructor(fn interface{}, params ...string)
+}
+
 type withConverterOpt interface {
 	WithConverter(converter.Converter)
 }
@@ -19,10 +27,18 @@ type withConverterFactoryOpt interface {
 	WithConverterFactory(converter.Factory)
 }
 
+type withDeepCopyOpt interface {
+	WithDeepCopy()
+}
+
 type withFieldOpt interface {
 	WithField(name string, opts ...func(fieldOpts))
 }
 
+type withFlattenEmbeddedOpt interface {
+	WithFlattenEmbedded()
+}
+
 type withIgnoreOpt interface {
 	WithIgnore()
 }
@@ -35,18 +51,33 @@ type withNamingStrategyOpt interface {
 	WithNamingStrategy(naming.Strategy)
 }
 
+type withOmitEmptyOpt interface {
+	WithOmitEmpty()
+}
+
+type withReverseOpt interface {
+	WithReverse()
+}
+
 type fieldOpts interface {
 	withAccessorOpt
+	withBinderOpt
 	withConverterOpt
+	withDeepCopyOpt
 	withIgnoreOpt
 	withMapperOpt
 	withNamingStrategyOpt
+	withOmitEmptyOpt
 }
 
 type structOpts interface {
+	withConstructorOpt
 	withConverterFactoryOpt
+	withDeepCopyOpt
 	withFieldOpt
+	withFlattenEmbeddedOpt
 	withNamingStrategyOpt
+	withReverseOpt
 }
 
 func WithFieldAccessor(a accessor.Accessor) func(fieldOpts) {
@@ -55,12 +86,24 @@ func WithFieldAccessor(a accessor.Accessor) func(fieldOpts) {
 	}
 }
 
+func WithFieldBinder(b accessor.Binder) func(fieldOpts) {
+	return func(opt fieldOpts) {
+		opt.WithBinder(b)
+	}
+}
+
 func WithFieldConverter(c converter.Converter) func(fieldOpts) {
 	return func(opt fieldOpts) {
 		opt.WithConverter(c)
 	}
 }
 
+func WithFieldDeepCopy() func(fieldOpts) {
+	return func(opt fieldOpts) {
+		opt.WithDeepCopy()
+	}
+}
+
 func WithFieldIgnore() func(fieldOpts) {
 	return func(opt fieldOpts) {
 		opt.WithIgnore()
@@ -79,20 +122,50 @@ func WithFieldNamingConvention(ns naming.Strategy) func(fieldOpts) {
 	}
 }
 
+func WithFieldOmitEmpty() func(fieldOpts) {
+	return func(opt fieldOpts) {
+		opt.WithOmitEmpty()
+	}
+}
+
+func WithStructConstructor(fn interface{}, params ...string) func(structOpts) {
+	return func(opt structOpts) {
+		opt.WithConstructor(fn, params...)
+	}
+}
+
 func WithStructConverterFactory(cf converter.Factory) func(structOpts) {
 	return func(opt structOpts) {
 		opt.WithConverterFactory(cf)
 	}
 }
 
+func WithStructDeepCopy() func(structOpts) {
+	return func(opt structOpts) {
+		opt.WithDeepCopy()
+	}
+}
+
 func WithStructField(name string, opts ...func(fieldOpts)) func(structOpts) {
 	return func(opt structOpts) {
 		opt.WithField(name, opts...)
 	}
 }
 
+func WithStructFlattenEmbedded() func(structOpts) {
+	return func(opt structOpts) {
+		opt.WithFlattenEmbedded()
+	}
+}
+
 func WithStructNamingConvention(ns naming.Strategy) func(structOpts) {
 	return func(opt structOpts) {
 		opt.WithNamingStrategy(ns)
 	}
 }
+
+func WithStructReverse() func(structOpts) {
+	return func(opt structOpts) {
+		opt.WithReverse()
+	}
+}
@@ -1,11 +1,15 @@
 package auto
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"unicode"
 
 	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
 	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/auto/mutator"
 	"github.com/craiggwilson/go-mapper/pkg/auto/naming"
 	"github.com/craiggwilson/go-mapper/pkg/core"
 	"github.com/craiggwilson/go-mapper/pkg/internal"
@@ -15,6 +19,7 @@ import (
 func NewProvider() *Provider {
 	return &Provider{
 		converterFactory: converter.FactoryFunc(converter.For),
+		getterPrefixes:   []string{"Get"},
 		namingStrategy:   naming.PascalCase{},
 	}
 }
@@ -23,30 +28,90 @@ func NewProvider() *Provider {
 type Provider struct {
 	// strategies
 	converterFactory converter.Factory
+	getterPrefixes   []string
 	namingStrategy   naming.Strategy
+	setterPrefixes   []string
 
-	structs []*Struct
+	converters      map[string]converter.Converter
+	deepCopy        bool
+	flattenEmbedded bool
+	strict          bool
+	structs         []*Struct
 }
 
-// Mappers implements the core.Provider interface.
+// Mappers implements the core.Provider interface. In strict mode, an UnmappedFieldsError is returned when any
+// destination field is left unmapped, and an IrreversibleFieldsError when any cannot be reversed.
 func (p *Provider) Mappers() ([]core.Mapper, error) {
-	mappers := make([]core.Mapper, 0, len(p.structs))
-	for _, opt := range p.structs {
-		mapper, err := p.createMapper(opt)
-		if err != nil {
+	mappers, report, err := p.createMappers()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.strict {
+		if err := report.err(); err != nil {
 			return nil, err
 		}
-		mappers = append(mappers, mapper)
 	}
 
 	return mappers, nil
 }
 
+// Validate checks that every destination field has an accessor, a custom mapper or is ignored, and that every
+// reversed mapping can be reversed, returning an UnmappedFieldsError or IrreversibleFieldsError listing the
+// fields that do not. When both apply, the errors are joined.
+func (p *Provider) Validate() error {
+	_, report, err := p.createMappers()
+	if err != nil {
+		return err
+	}
+
+	return report.err()
+}
+
 // WithConverterFactory applies the converterFactory to all future uses.
 func (p *Provider) WithConverterFactory(cf converter.Factory) {
 	p.converterFactory = cf
 }
 
+// WithConverter registers a converter under name, so that it can be referenced by a struct tag.
+func (p *Provider) WithConverter(name string, c converter.Converter) {
+	if p.converters == nil {
+		p.converters = make(map[string]converter.Converter)
+	}
+	p.converters[name] = c
+}
+
+// WithDeepCopy causes values of the same type to be cloned rather than assigned, so that no mapped destination
+// shares pointers, slices or maps with its source.
+func (p *Provider) WithDeepCopy(enabled bool) {
+	p.deepCopy = enabled
+}
+
+// WithFlattenEmbedded causes the fields of structs embedded in a destination to be mapped one by one, as though
+// they were declared on the destination, rather than mapping the embedded struct as a whole. Embedded pointers
+// are allocated when there is a value to set.
+func (p *Provider) WithFlattenEmbedded(enabled bool) {
+	p.flattenEmbedded = enabled
+}
+
+// WithGetterPrefixes sets the prefixes a getter method may have in addition to the name of the member it
+// provides, such that GetTotal provides Total. The default is Get.
+func (p *Provider) WithGetterPrefixes(prefixes ...string) {
+	p.getterPrefixes = prefixes
+}
+
+// WithSetterPrefixes enables setting destination members through setter methods named with one of the
+// prefixes, such that SetTotal or WithTotal sets Total. A setter is preferred over the exported field of the
+// same name, so that the invariants it enforces are not bypassed. Setters are not used by default.
+func (p *Provider) WithSetterPrefixes(prefixes ...string) {
+	p.setterPrefixes = prefixes
+}
+
+// WithStrict causes Mappers to fail when any destination field is left unmapped or cannot be reversed.
+func (p *Provider) WithStrict(enabled bool) {
+	p.strict = enabled
+}
+
 // WithNamingConvention applies the naming convention to all future uses.
 func (p *Provider) WithNamingConvention(ns naming.Strategy) {
 	p.namingStrategy = ns
@@ -71,121 +136,312 @@ func (p *Provider) Add(dst reflect.Type, src reflect.Type, opts ...func(structOp
 	p.structs = append(p.structs, &s)
 }
 
-func (p *Provider) createMapper(s *Struct) (core.Mapper, error) {
-	converterFactory := s.converterFactory
-	if converterFactory == nil {
-		converterFactory = p.converterFactory
+// createMappers creates the core.Mappers for every struct, along with a report of the destination fields that
+// are unmapped or irreversible.
+func (p *Provider) createMappers() ([]core.Mapper, fieldReport, error) {
+	mappers := make([]core.Mapper, 0, len(p.structs))
+	var report fieldReport
+	for _, s := range withNestedMaps(p.structs) {
+		if isStringMap(s.dst) {
+			members, err := p.createMapMembers(s)
+			if err != nil {
+				return nil, fieldReport{}, err
+			}
+			mappers = append(mappers, newStructMapper(s.dst, s.src, nil, members))
+			continue
+		}
+
+		members, unmapped, err := p.createMembers(s)
+		if err != nil {
+			return nil, fieldReport{}, err
+		}
+		ctor, err := p.createConstructor(s)
+		if err != nil {
+			return nil, fieldReport{}, fmt.Errorf("mapping %v: %w", s.dst.Name(), err)
+		}
+		mappers = append(mappers, newStructMapper(s.dst, s.src, ctor, members))
+		report.unmapped = append(report.unmapped, unmapped...)
+
+		if s.reverse {
+			reversed, irreversible := p.reverseMembers(s, members)
+			mappers = append(mappers, newStructMapper(s.src, s.dst, nil, reversed))
+			report.irreversible = append(report.irreversible, irreversible...)
+		}
+	}
+
+	return mappers, report, nil
+}
+
+// fieldReport lists the destination fields that a Provider's configuration leaves unmapped or irreversible.
+type fieldReport struct {
+	unmapped     []string
+	irreversible []string
+}
+
+// err returns an UnmappedFieldsError and an IrreversibleFieldsError for the fields in the report, joined when
+// both apply, or nil when there are none.
+func (r fieldReport) err() error {
+	var errs []error
+	if len(r.unmapped) > 0 {
+		errs = append(errs, &UnmappedFieldsError{Fields: r.unmapped})
 	}
-	namingStrategy := s.namingStrategy
-	if namingStrategy == nil {
-		namingStrategy = p.namingStrategy
+	if len(r.irreversible) > 0 {
+		errs = append(errs, &IrreversibleFieldsError{Fields: r.irreversible})
 	}
 
-	fields := make(map[string]*Field, s.dst.NumField())
-	for k, fld := range s.fields {
-		fields[k] = fld
+	return errors.Join(errs...)
+}
+
+func (p *Provider) converterFactoryFor(s *Struct) converter.Factory {
+	cf := p.converterFactory
+	if s.converterFactory != nil {
+		cf = s.converterFactory
 	}
+	if p.deepCopy || s.deepCopy {
+		cf = converter.DeepCopy(cf)
+	}
+	return cf
+}
 
-	for i := 0; i < s.dst.NumField(); i++ {
-		fld := s.dst.Field(i)
-		f, ok := fields[fld.Name]
-		if ok && f.mapper != nil {
-			// If we have a mapper already, we don't need to do any automapping work.
-			continue
-		} else if !ok {
-			f = &Field{
-				dst: fld,
-			}
+func (p *Provider) namingStrategyFor(s *Struct) naming.Strategy {
+	if s.namingStrategy != nil {
+		return s.namingStrategy
+	}
+	return p.namingStrategy
+}
+
+// createMembers creates the members for s, along with the destination members that have nothing to map them.
+// A destination field with a setter method is set by calling the setter, and setters for which there is no
+// exported field are mapped as members of their own.
+func (p *Provider) createMembers(s *Struct) ([]*member, []string, error) {
+	setters := p.setters(s.dst)
+	used := make(map[string]bool, len(setters))
+
+	members := make([]*member, 0, s.dst.NumField())
+	var unmapped []string
+	add := func(f *Field, target *member, name string) error {
+		ms, um, err := p.createMember(s, f, target, name)
+		members = append(members, ms...)
+		unmapped = append(unmapped, um...)
+		return err
+	}
+
+	fields, err := p.dstFields(s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mapping %v: %w", s.dst.Name(), err)
+	}
+
+	for _, fld := range fields {
+		f, t, err := p.taggedField(s, fld)
+		if err != nil {
+			return nil, nil, fmt.Errorf("mapping %v: %w", s.dst.Name(), err)
+		}
+		if explicit, ok := s.fields[fld.Name]; ok {
+			f = explicit.over(f)
 		}
 
 		if f.ignore {
+			used[fld.Name] = true
 			continue
 		}
 
-		acc := f.accessor
-		if acc == nil {
-			acc = findAccessor(namingStrategy, fld.Name, s.src)
-			if acc == nil {
-				continue
+		target := &member{path: []reflect.StructField{fld}}
+		for _, set := range setters {
+			if set.name == fld.Name {
+				target = &member{mutator: set.mutator}
+				used[set.name] = true
+			}
+		}
+
+		if err = add(f, target, t.memberName(fld)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, set := range setters {
+		if !used[set.name] {
+			if err := add(&Field{}, &member{mutator: set.mutator}, set.name); err != nil {
+				return nil, nil, err
 			}
 		}
+	}
+
+	return members, unmapped, nil
+}
+
+// dstFields returns the exported fields of the dst of s, with the fields of embedded structs promoted in their
+// place when flattening embedded structs.
+func (p *Provider) dstFields(s *Struct) ([]reflect.StructField, error) {
+	if p.flattenEmbedded || s.flattenEmbedded {
+		return promotedFields(s.dst, func(fld reflect.StructField) (bool, error) {
+			if f, ok := s.fields[fld.Name]; ok && f.ignore {
+				return true, nil
+			}
+			t, err := parseTag(fld)
+			return t.ignore, err
+		})
+	}
+
+	fields := make([]reflect.StructField, 0, s.dst.NumField())
+	for i := 0; i < s.dst.NumField(); i++ {
+		// Unexported fields cannot be set.
+		if fld := s.dst.Field(i); fld.PkgPath == "" {
+			fields = append(fields, fld)
+		}
+	}
+	return fields, nil
+}
+
+// createMember creates the members that map the destination target, which holds the path or mutator of the
+// destination, and is known to the naming strategy by name.
+func (p *Provider) createMember(s *Struct, f *Field, target *member, name string) ([]*member, []string, error) {
+	dstName := fmt.Sprintf("%v.%s", s.dst.Name(), target.name())
+	dstType := target.dstType()
+
+	if f.mapper != nil {
+		// If we have a mapper already, we don't need to do any automapping work.
+		target.mapFn = f.mapper.Map
+		return []*member{target}, nil, nil
+	}
 
-		conv := f.converter
-		if conv == nil {
-			var err error
-			conv, err = converterFactory.ConverterFor(fld.Type, acc.Type())
+	cf := p.converterFactoryFor(s)
+	if f.deepCopy {
+		cf = converter.DeepCopy(cf)
+	}
+
+	acc := f.accessor
+	if f.binder != nil {
+		var err error
+		if acc, err = f.binder.Bind(s.src, fieldByName); err != nil {
+			return nil, nil, fmt.Errorf("mapping field %q: %w", dstName, err)
+		}
+	}
+	if acc == nil {
+		ns := f.namingStrategy
+		if ns == nil {
+			ns = p.namingStrategyFor(s)
+		}
+		var err error
+		if acc, err = findAccessor(ns, p.getterPrefixes, name, s.src); err != nil {
+			return nil, nil, fmt.Errorf("mapping field %q: %w", dstName, err)
+		}
+		if acc == nil && f.converter == nil && target.mutator == nil {
+			// Attempt to populate the fields of a nested struct from flattened names.
+			nested, nestedUnmapped, err := p.unflatten(ns, cf, s, target.path, name)
 			if err != nil {
-				return nil, fmt.Errorf("mapping field %q from %q: %w",
-					fmt.Sprintf("%v.%s", s.dst.Name(), fld.Name),
-					fmt.Sprintf("%v.%s", s.src.Name(), acc.Name()),
-					err)
+				return nil, nil, err
+			}
+			if len(nested) > 0 {
+				return nested, nestedUnmapped, nil
 			}
 		}
+		if acc == nil {
+			return nil, []string{dstName}, nil
+		}
+	}
+
+	if f.converter != nil {
+		target.mapFn = convertFunc(f.converter)
+		target.customConverter = true
+	} else {
+		mapFn, err := mapFuncFor(cf, dstType, acc.Type())
+		if err != nil {
+			return nil, nil, fmt.Errorf("mapping field %q from %q: %w",
+				dstName,
+				fmt.Sprintf("%v.%s", typeName(s.src), acc.Name()),
+				err)
+		}
+		target.mapFn = mapFn
+	}
+
+	target.accessor = acc
+	target.omitEmpty = f.omitEmpty
+	return []*member{target}, nil, nil
+}
+
+// setter is a setter method found on a destination type, along with the name of the member it sets.
+type setter struct {
+	name    string
+	mutator mutator.Mutator
+}
+
+// setters returns the setter methods of t, named with one of the setter prefixes followed by the name of the
+// member they set.
+func (p *Provider) setters(t reflect.Type) []setter {
+	if len(p.setterPrefixes) == 0 {
+		return nil
+	}
+
+	var setters []setter
+	pt := reflect.PtrTo(t)
+	for i := 0; i < pt.NumMethod(); i++ {
+		m := pt.Method(i)
+		if !mutator.IsSetter(m) {
+			continue
+		}
 
-		fields[fld.Name] = &Field{
-			dst:       fld,
-			accessor:  acc,
-			converter: conv,
-			mapper: core.NewFunctionMapper(
-				fld.Type,
-				s.src,
-				func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
-					if src.IsNil() {
-						return nil
-					}
-
-					src = acc.ValueFrom(src)
-
-					if conv != nil {
-						return conv.Convert(dst, src)
-					}
-
-					dst = internal.EnsureSettableDst(dst)
-					dst.Set(internal.UnwrapPtrValue(src))
-					return nil
-				},
-			),
-		}
-	}
-
-	return core.NewFunctionMapper(
-		s.dst,
-		s.src,
-		func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
-			dst = internal.EnsureSettableDst(dst)
-			for _, fld := range fields {
-				fv := dst.FieldByIndex(fld.dst.Index)
-				if fld.ignore {
-					continue
-				}
-
-				if !fv.CanAddr() {
-					return fmt.Errorf("field %q cannot be addressed", fld.dst.Name)
-				}
-
-				if err := fld.mapper.Map(ctx, fv.Addr(), src); err != nil {
-					name := "(custom function)"
-					if fld.accessor != nil {
-						name = fld.accessor.Name()
-					}
-					return fmt.Errorf("mapping field %q from %q: %w",
-						fmt.Sprintf("%v.%s", dst.Type(), fld.dst.Name),
-						fmt.Sprintf("%v.%s", src.Type(), name),
-						err)
-				}
+		for _, prefix := range p.setterPrefixes {
+			name := strings.TrimPrefix(m.Name, prefix)
+			if name == m.Name || name == "" || !unicode.IsUpper([]rune(name)[0]) {
+				continue
 			}
 
-			return nil
-		},
-	), nil
+			setters = append(setters, setter{name: name, mutator: mutator.Method(m)})
+			break
+		}
+	}
+
+	return setters
+}
+
+// reverseMembers creates the members that map from the dst of s back into the src of s, along with the
+// destination fields of s whose mapping cannot be reversed. Only members that read a chain of struct fields
+// into fields with the converter factory can be reversed, as a custom converter only converts in one direction.
+func (p *Provider) reverseMembers(s *Struct, members []*member) ([]*member, []string) {
+	converterFactory := p.converterFactoryFor(s)
+
+	reversed := make([]*member, 0, len(members))
+	var irreversible []string
+	for _, m := range members {
+		name := fmt.Sprintf("%v.%s", s.dst.Name(), m.name())
+		if m.accessor == nil || m.mutator != nil || m.customConverter {
+			irreversible = append(irreversible, name)
+			continue
+		}
+
+		path, ok := fieldPath(m.accessor)
+		if !ok {
+			irreversible = append(irreversible, name)
+			continue
+		}
+
+		mapFn, err := mapFuncFor(converterFactory, path[len(path)-1].Type, m.path[len(m.path)-1].Type)
+		if err != nil {
+			irreversible = append(irreversible, name)
+			continue
+		}
+
+		reversed = append(reversed, &member{
+			path:     path,
+			accessor: pathAccessor(m.path),
+			mapFn:    mapFn,
+		})
+	}
+
+	return reversed, irreversible
 }
 
 type Struct struct {
 	dst reflect.Type
 	src reflect.Type
 
-	converterFactory converter.Factory
-	namingStrategy   naming.Strategy
+	constructor       reflect.Value
+	constructorParams []string
+	converterFactory  converter.Factory
+	deepCopy          bool
+	flattenEmbedded   bool
+	namingStrategy    naming.Strategy
+	reverse           bool
 
 	fields map[string]*Field
 }
@@ -198,10 +454,25 @@ func (s *Struct) Src() reflect.Type {
 	return s.src
 }
 
+// WithConstructor creates each destination by calling fn, which must return the destination type, or a pointer
+// to it, and optionally an error. As Go does not expose parameter names, params names the src member used for
+// each parameter in order, matched with the naming strategy. Without params, each parameter takes the exported
+// src field in the same position. Exported destination fields are still mapped afterwards, unless ignored.
+func (s *Struct) WithConstructor(fn interface{}, params ...string) {
+	v := reflect.ValueOf(fn)
+	validateConstructor(s.dst, v, params)
+	s.constructor = v
+	s.constructorParams = params
+}
+
 func (s *Struct) WithConverterFactory(cf converter.Factory) {
 	s.converterFactory = cf
 }
 
+func (s *Struct) WithDeepCopy() {
+	s.deepCopy = true
+}
+
 func (s *Struct) WithField(name string, opts ...func(fieldOpts)) {
 	sf, found := s.dst.FieldByName(name)
 	if !found {
@@ -218,29 +489,85 @@ func (s *Struct) WithField(name string, opts ...func(fieldOpts)) {
 	s.fields[sf.Name] = &f
 }
 
+// WithFlattenEmbedded maps the fields of structs embedded in the destination one by one, see
+// Provider.WithFlattenEmbedded.
+func (s *Struct) WithFlattenEmbedded() {
+	s.flattenEmbedded = true
+}
+
 func (s *Struct) WithNamingStrategy(ns naming.Strategy) {
 	s.namingStrategy = ns
 }
 
+func (s *Struct) WithReverse() {
+	s.reverse = true
+}
+
 // Field contains options for a field mapping.
 type Field struct {
 	dst reflect.StructField
 
 	accessor       accessor.Accessor
+	binder         accessor.Binder
 	converter      converter.Converter
+	deepCopy       bool
 	ignore bool
 	mapper         core.Mapper
 	namingStrategy naming.Strategy
+	omitEmpty      bool
+}
+
+// over returns a copy of base with the options set on f layered on top.
+func (f *Field) over(base *Field) *Field {
+	merged := *base
+	if f.accessor != nil {
+		merged.accessor = f.accessor
+		merged.binder = nil
+		merged.ignore = false
+	}
+	if f.binder != nil {
+		merged.binder = f.binder
+		merged.accessor = nil
+		merged.ignore = false
+	}
+	if f.converter != nil {
+		merged.converter = f.converter
+	}
+	if f.mapper != nil {
+		merged.mapper = f.mapper
+		merged.ignore = false
+	}
+	if f.deepCopy {
+		merged.deepCopy = true
+	}
+	if f.namingStrategy != nil {
+		merged.namingStrategy = f.namingStrategy
+	}
+	if f.ignore {
+		merged.ignore = true
+	}
+	if f.omitEmpty {
+		merged.omitEmpty = true
+	}
+	return &merged
 }
 
 func (f *Field) WithAccessor(a accessor.Accessor) {
 	f.accessor = a
 }
 
+func (f *Field) WithBinder(b accessor.Binder) {
+	f.binder = b
+}
+
 func (f *Field) WithConverter(c converter.Converter) {
 	f.converter = c
 }
 
+func (f *Field) WithDeepCopy() {
+	f.deepCopy = true
+}
+
 func (f *Field) WithIgnore() {
 	f.ignore = true
 }
@@ -252,3 +579,7 @@ func (f *Field) WithMapper(m core.Mapper) {
 func (f *Field) WithNamingStrategy(ns naming.Strategy) {
 	f.namingStrategy = ns
 }
+
+func (f *Field) WithOmitEmpty() {
+	f.omitEmpty = true
+}
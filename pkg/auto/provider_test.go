@@ -1,13 +1,21 @@
 package auto_test
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/craiggwilson/go-mapper"
 	"github.com/craiggwilson/go-mapper/pkg/auto"
+	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
+	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/core"
 )
 
 func TestSimple(t *testing.T) {
@@ -134,6 +142,46 @@ func TestFieldOpts(t *testing.T) {
 		_, err := ap.Mappers()
 		require.NoError(t, err)
 	})
+
+	t.Run("custom accessor", func(t *testing.T) {
+		t.Parallel()
+		type order struct {
+			Number string
+		}
+		type orderDTO struct {
+			Number string
+		}
+
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructField("Number", auto.WithFieldAccessor(upperAccessor{"Number"})),
+		)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst orderDTO
+		require.NoError(t, m.Map(&dst, &order{Number: "abc"}))
+		require.Equal(t, "ABC", dst.Number)
+	})
+}
+
+// upperAccessor reads a string field in upper case, implementing only the Accessor interface.
+type upperAccessor struct {
+	field string
+}
+
+func (a upperAccessor) Name() string {
+	return a.field
+}
+
+func (a upperAccessor) Type() reflect.Type {
+	return reflect.TypeOf("")
+}
+
+func (a upperAccessor) ValueFrom(v reflect.Value) reflect.Value {
+	return reflect.ValueOf(strings.ToUpper(reflect.Indirect(v).FieldByName(a.field).String()))
 }
 
 func TestFlatten(t *testing.T) {
@@ -168,3 +216,1279 @@ func TestFlatten(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, src.Customer.Name, dst.CustomerName)
 }
+
+func TestNested(t *testing.T) {
+	t.Parallel()
+	type address struct {
+		City string
+	}
+	type customer struct {
+		Name    string
+		Address address
+	}
+	type order struct {
+		Customer *customer
+	}
+	type addressDTO struct {
+		City string
+	}
+	type customerDTO struct {
+		Name    string
+		Address *addressDTO
+	}
+	type orderDTO struct {
+		Customer *customerDTO
+	}
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(orderDTO)),
+		reflect.TypeOf(new(order)),
+	)
+	ap.Add(
+		reflect.TypeOf(new(customerDTO)),
+		reflect.TypeOf(new(customer)),
+	)
+	ap.Add(
+		reflect.TypeOf(new(addressDTO)),
+		reflect.TypeOf(new(address)),
+	)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	t.Run("populated", func(t *testing.T) {
+		src := order{
+			Customer: &customer{
+				Name: "Blockus",
+				Address: address{
+					City: "Dallas",
+				},
+			},
+		}
+		var dst orderDTO
+		err := m.Map(&dst, &src)
+		require.NoError(t, err)
+		require.NotNil(t, dst.Customer)
+		require.Equal(t, src.Customer.Name, dst.Customer.Name)
+		require.NotNil(t, dst.Customer.Address)
+		require.Equal(t, src.Customer.Address.City, dst.Customer.Address.City)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var dst orderDTO
+		err := m.Map(&dst, &order{})
+		require.NoError(t, err)
+		require.Nil(t, dst.Customer)
+	})
+
+	t.Run("no registered mapper", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+		)
+
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst orderDTO
+		err = m.Map(&dst, &order{Customer: &customer{}})
+		require.True(t, errors.Is(err, mapper.ErrNoTypeMapperFound))
+	})
+}
+
+func TestCollections(t *testing.T) {
+	t.Parallel()
+	type item struct {
+		Sku string
+	}
+	type order struct {
+		Lines   []item
+		Items   map[string]*item
+		Numbers [3]string
+		Tags    []string
+	}
+	type itemDTO struct {
+		Sku string
+	}
+	type orderDTO struct {
+		Lines   []*itemDTO
+		Items   map[string]itemDTO
+		Numbers []int
+		Tags    [2]string
+	}
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(orderDTO)),
+		reflect.TypeOf(new(order)),
+	)
+	ap.Add(
+		reflect.TypeOf(new(itemDTO)),
+		reflect.TypeOf(new(item)),
+	)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	t.Run("populated", func(t *testing.T) {
+		src := order{
+			Lines: []item{{Sku: "a"}, {Sku: "b"}},
+			Items: map[string]*item{
+				"a": {Sku: "a"},
+				"b": nil,
+			},
+			Numbers: [3]string{"1", "2", "3"},
+			Tags:    []string{"x"},
+		}
+		var dst orderDTO
+		err := m.Map(&dst, &src)
+		require.NoError(t, err)
+		require.Equal(t, []*itemDTO{{Sku: "a"}, {Sku: "b"}}, dst.Lines)
+		require.Equal(t, map[string]itemDTO{"a": {Sku: "a"}, "b": {}}, dst.Items)
+		require.Equal(t, []int{1, 2, 3}, dst.Numbers)
+		require.Equal(t, [2]string{"x", ""}, dst.Tags)
+	})
+
+	t.Run("nil and empty", func(t *testing.T) {
+		src := order{
+			Items: map[string]*item{},
+		}
+		var dst orderDTO
+		err := m.Map(&dst, &src)
+		require.NoError(t, err)
+		require.Nil(t, dst.Lines)
+		require.NotNil(t, dst.Items)
+		require.Empty(t, dst.Items)
+	})
+
+	t.Run("array too small", func(t *testing.T) {
+		src := order{
+			Tags: []string{"x", "y", "z"},
+		}
+		var dst orderDTO
+		err := m.Map(&dst, &src)
+		require.Error(t, err)
+	})
+
+	t.Run("map value fails", func(t *testing.T) {
+		type counts struct {
+			Totals map[string]string
+		}
+		type countsDTO struct {
+			Totals map[int]int
+		}
+
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(countsDTO)),
+			reflect.TypeOf(new(counts)),
+		)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst countsDTO
+		err = m.Map(&dst, &counts{Totals: map[string]string{"1": "one"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "mapping value for key 1:")
+
+		err = m.Map(&dst, &counts{Totals: map[string]string{"one": "1"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "mapping key one:")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	type order struct {
+		Number string
+	}
+	type orderDTO struct {
+		Number   string
+		Total    int
+		Discount int
+		Notes    string
+		internal string
+	}
+
+	newProvider := func() *auto.Provider {
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructField("Notes", auto.WithFieldIgnore()),
+		)
+		return ap
+	}
+
+	t.Run("Validate", func(t *testing.T) {
+		ap := newProvider()
+		err := ap.Validate()
+		var ufe *auto.UnmappedFieldsError
+		require.True(t, errors.As(err, &ufe))
+		require.Equal(t, []string{"orderDTO.Total", "orderDTO.Discount"}, ufe.Fields)
+
+		_, err = ap.Mappers()
+		require.NoError(t, err)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		ap := newProvider()
+		ap.WithStrict(true)
+
+		_, err := mapper.New(ap)
+		var ufe *auto.UnmappedFieldsError
+		require.True(t, errors.As(err, &ufe))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructField("Total", auto.WithFieldIgnore()),
+			auto.WithStructField("Discount", auto.WithFieldMapper(core.MapperFromFunc(func(dst *int, src *order) error {
+				*dst = 10
+				return nil
+			}))),
+			auto.WithStructField("Notes", auto.WithFieldIgnore()),
+		)
+		ap.WithStrict(true)
+
+		require.NoError(t, ap.Validate())
+		_, err := ap.Mappers()
+		require.NoError(t, err)
+	})
+}
+
+func TestReverse(t *testing.T) {
+	t.Parallel()
+	type customer struct {
+		Name string
+	}
+	type order struct {
+		Number   int
+		Customer *customer
+	}
+	type orderDTO struct {
+		Number       string
+		CustomerName string
+	}
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(orderDTO)),
+		reflect.TypeOf(new(order)),
+		auto.WithStructReverse(),
+	)
+	require.NoError(t, ap.Validate())
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	t.Run("forward", func(t *testing.T) {
+		src := order{
+			Number:   42,
+			Customer: &customer{Name: "Blockus"},
+		}
+		var dst orderDTO
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, orderDTO{Number: "42", CustomerName: "Blockus"}, dst)
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		src := orderDTO{
+			Number:       "42",
+			CustomerName: "Blockus",
+		}
+		var dst order
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, 42, dst.Number)
+		require.NotNil(t, dst.Customer)
+		require.Equal(t, "Blockus", dst.Customer.Name)
+	})
+
+	t.Run("reverse without flattened value", func(t *testing.T) {
+		var dst order
+		require.NoError(t, m.Map(&dst, &orderDTO{Number: "42"}))
+		require.Nil(t, dst.Customer)
+	})
+
+	t.Run("irreversible", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructReverse(),
+			auto.WithStructField("Number", auto.WithFieldMapper(core.MapperFromFunc(func(dst *string, src *order) error {
+				*dst = strconv.Itoa(src.Number)
+				return nil
+			}))),
+		)
+
+		err := ap.Validate()
+		var ife *auto.IrreversibleFieldsError
+		require.True(t, errors.As(err, &ife))
+		require.Equal(t, []string{"orderDTO.Number"}, ife.Fields)
+
+		var ufe *auto.UnmappedFieldsError
+		require.False(t, errors.As(err, &ufe))
+	})
+
+	t.Run("custom converter", func(t *testing.T) {
+		type product struct {
+			Price int64
+		}
+		type productDTO struct {
+			Price float64
+		}
+
+		centsToDollars := converter.Func(func(dst reflect.Value, src reflect.Value) error {
+			dst.Elem().SetFloat(float64(src.Int()) / 100)
+			return nil
+		})
+
+		ap := auto.NewProvider()
+		ap.WithStrict(true)
+		ap.Add(
+			reflect.TypeOf(new(productDTO)),
+			reflect.TypeOf(new(product)),
+			auto.WithStructReverse(),
+			auto.WithStructField("Price", auto.WithFieldConverter(centsToDollars)),
+		)
+
+		err := ap.Validate()
+		var ife *auto.IrreversibleFieldsError
+		require.True(t, errors.As(err, &ife))
+		require.Equal(t, []string{"productDTO.Price"}, ife.Fields)
+
+		_, err = mapper.New(ap)
+		require.True(t, errors.As(err, &ife))
+	})
+}
+
+func TestUnflatten(t *testing.T) {
+	t.Parallel()
+	type address struct {
+		City string
+	}
+	type customer struct {
+		Name    string
+		Email   string
+		Address *address
+	}
+	type order struct {
+		Number   int
+		Customer *customer
+	}
+	type orderForm struct {
+		Number              string
+		CustomerName        string
+		CustomerAddressCity string
+	}
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(order)),
+		reflect.TypeOf(new(orderForm)),
+	)
+
+	err := ap.Validate()
+	var ufe *auto.UnmappedFieldsError
+	require.True(t, errors.As(err, &ufe))
+	require.Equal(t, []string{"order.Customer.Email"}, ufe.Fields)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	t.Run("populated", func(t *testing.T) {
+		src := orderForm{
+			Number:              "42",
+			CustomerName:        "Blockus",
+			CustomerAddressCity: "Dallas",
+		}
+		var dst order
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, 42, dst.Number)
+		require.NotNil(t, dst.Customer)
+		require.Equal(t, "Blockus", dst.Customer.Name)
+		require.NotNil(t, dst.Customer.Address)
+		require.Equal(t, "Dallas", dst.Customer.Address.City)
+	})
+
+	t.Run("partially populated", func(t *testing.T) {
+		src := orderForm{
+			CustomerName: "Blockus",
+		}
+		var dst order
+		require.NoError(t, m.Map(&dst, &src))
+		require.NotNil(t, dst.Customer)
+		require.Equal(t, "Blockus", dst.Customer.Name)
+		require.Nil(t, dst.Customer.Address)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var dst order
+		require.NoError(t, m.Map(&dst, &orderForm{Number: "42"}))
+		require.Nil(t, dst.Customer)
+	})
+
+	t.Run("clears existing", func(t *testing.T) {
+		dst := order{Customer: &customer{Name: "old", Email: "old@example.com"}}
+		require.NoError(t, m.Map(&dst, &orderForm{}))
+		require.Equal(t, &customer{Email: "old@example.com"}, dst.Customer)
+	})
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+	type customer struct {
+		Name string
+	}
+	type order struct {
+		Number   int
+		Secret   string `mapper:"-"`
+		Code     string `mapper:"name=Reference"`
+		Customer *customer
+		Status   int
+		Notes    string
+	}
+	type orderDTO struct {
+		Number    string `mapper:"omitempty"`
+		Secret    string
+		Reference string
+		Buyer     string `mapper:"from=Customer.Name"`
+		Status    string `mapper:"converter=status"`
+		Internal  string `mapper:"-"`
+		Comments  string `mapper:"name=Notes"`
+	}
+
+	statusConverter := converter.Func(func(dst reflect.Value, src reflect.Value) error {
+		if src.Int() == 1 {
+			dst.Elem().SetString("open")
+		}
+		return nil
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.WithConverter("status", statusConverter)
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructField("Secret", auto.WithFieldIgnore()),
+		)
+		require.NoError(t, ap.Validate())
+
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		src := order{
+			Secret:   "shh",
+			Code:     "abc",
+			Customer: &customer{Name: "Blockus"},
+			Status:   1,
+			Notes:    "fragile",
+		}
+		dst := orderDTO{
+			Number:   "keep",
+			Internal: "keep",
+		}
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, orderDTO{
+			Number:    "keep",
+			Reference: "abc",
+			Buyer:     "Blockus",
+			Status:    "open",
+			Internal:  "keep",
+			Comments:  "fragile",
+		}, dst)
+	})
+
+	t.Run("explicit options override tags", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.WithConverter("status", statusConverter)
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+			auto.WithStructField("Secret", auto.WithFieldIgnore()),
+			auto.WithStructField("Internal", auto.WithFieldAccessor(accessor.Field(reflect.TypeOf(order{}).Field(0)))),
+		)
+
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst orderDTO
+		require.NoError(t, m.Map(&dst, &order{Number: 42}))
+		require.Equal(t, "42", dst.Internal)
+	})
+
+	t.Run("unknown converter", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(orderDTO)),
+			reflect.TypeOf(new(order)),
+		)
+
+		_, err := ap.Mappers()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		type badDTO struct {
+			Buyer string `mapper:"from=Customer.Missing"`
+		}
+
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(badDTO)),
+			reflect.TypeOf(new(order)),
+		)
+
+		_, err := ap.Mappers()
+		require.Error(t, err)
+	})
+
+	t.Run("malformed source tag", func(t *testing.T) {
+		type badOrder struct {
+			Number int `mapper:"nmae=Number"`
+		}
+		type numberDTO struct {
+			Number int
+		}
+
+		ap := auto.NewProvider()
+		ap.Add(
+			reflect.TypeOf(new(numberDTO)),
+			reflect.TypeOf(new(badOrder)),
+		)
+
+		_, err := ap.Mappers()
+		require.EqualError(t, err, `mapping field "numberDTO.Number": reading auto_test.badOrder: invalid mapper tag option "nmae=Number" on field "Number"`)
+	})
+
+	t.Run("malformed embedded tag", func(t *testing.T) {
+		type Base struct {
+			Number int
+		}
+		type numberDTO struct {
+			Base `mapper:"omitempty,"`
+		}
+
+		ap := auto.NewProvider()
+		ap.WithFlattenEmbedded(true)
+		ap.Add(
+			reflect.TypeOf(new(numberDTO)),
+			reflect.TypeOf(new(order)),
+		)
+
+		_, err := ap.Mappers()
+		require.EqualError(t, err, `mapping numberDTO: invalid mapper tag option "" on field "Base"`)
+	})
+}
+
+func TestConverterRegistry(t *testing.T) {
+	t.Parallel()
+	type order struct {
+		Number int
+		Total  int
+	}
+	type orderDTO struct {
+		Number string
+		Total  string
+	}
+
+	r := converter.NewRegistry()
+	converter.Register(r, func(dst *string, src int) error {
+		*dst = fmt.Sprintf("#%d", src)
+		return nil
+	})
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(orderDTO)),
+		reflect.TypeOf(new(order)),
+		auto.WithStructConverterFactory(r),
+	)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	var dst orderDTO
+	require.NoError(t, m.Map(&dst, &order{Number: 42, Total: 10}))
+	require.Equal(t, orderDTO{Number: "#42", Total: "#10"}, dst)
+}
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	type row struct {
+		Name  sql.NullString
+		Age   sql.NullInt64
+		Email sql.NullString
+	}
+	type user struct {
+		Name  *string
+		Age   int
+		Email *string
+	}
+
+	ap := auto.NewProvider()
+	ap.Add(
+		reflect.TypeOf(new(user)),
+		reflect.TypeOf(new(row)),
+		auto.WithStructReverse(),
+	)
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	stale := "stale"
+	dst := user{Email: &stale}
+	require.NoError(t, m.Map(&dst, &row{
+		Name: sql.NullString{String: "Bob", Valid: true},
+		Age:  sql.NullInt64{Int64: 42, Valid: true},
+	}))
+	require.NotNil(t, dst.Name)
+	require.Equal(t, "Bob", *dst.Name)
+	require.Equal(t, 42, dst.Age)
+	require.Nil(t, dst.Email)
+
+	var back row
+	require.NoError(t, m.Map(&back, &dst))
+	require.Equal(t, row{
+		Name: sql.NullString{String: "Bob", Valid: true},
+		Age:  sql.NullInt64{Int64: 42, Valid: true},
+	}, back)
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+	type line struct {
+		Tags []string
+	}
+	type order struct {
+		Lines  []*line
+		Totals map[string]int
+		Notes  []string
+	}
+
+	src := order{
+		Lines:  []*line{{Tags: []string{"a"}}},
+		Totals: map[string]int{"usd": 1},
+		Notes:  []string{"n"},
+	}
+
+	orderType := reflect.TypeOf(new(order))
+	testCases := []struct {
+		name       string
+		configure  func(ap *auto.Provider)
+		notesShare bool
+		linesShare bool
+	}{
+		{
+			name:       "shallow by default",
+			configure:  func(ap *auto.Provider) { ap.Add(orderType, orderType) },
+			notesShare: true,
+			linesShare: true,
+		},
+		{
+			name: "provider",
+			configure: func(ap *auto.Provider) {
+				ap.WithDeepCopy(true)
+				ap.Add(orderType, orderType)
+			},
+		},
+		{
+			name:      "struct",
+			configure: func(ap *auto.Provider) { ap.Add(orderType, orderType, auto.WithStructDeepCopy()) },
+		},
+		{
+			name: "field",
+			configure: func(ap *auto.Provider) {
+				ap.Add(orderType, orderType, auto.WithStructField("Lines", auto.WithFieldDeepCopy()))
+			},
+			notesShare: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ap := auto.NewProvider()
+			tc.configure(ap)
+
+			m, err := mapper.New(ap)
+			require.NoError(t, err)
+
+			var dst order
+			require.NoError(t, m.Map(&dst, &src))
+			require.Equal(t, src, dst)
+			require.Equal(t, tc.notesShare, &dst.Notes[0] == &src.Notes[0])
+			require.Equal(t, tc.linesShare, dst.Lines[0] == src.Lines[0])
+		})
+	}
+}
+
+type money struct {
+	amount   int64
+	currency string
+	Note     string
+}
+
+func newMoney(amount int64, currency string) (*money, error) {
+	if currency == "" {
+		return nil, errors.New("currency is required")
+	}
+	return &money{amount: amount, currency: currency}, nil
+}
+
+func TestConstructor(t *testing.T) {
+	t.Parallel()
+	type moneyDTO struct {
+		Code   string
+		Amount string
+		Note   string
+	}
+
+	testCases := []struct {
+		name string
+		opt  func(ap *auto.Provider)
+	}{
+		{
+			name: "named parameters",
+			opt: func(ap *auto.Provider) {
+				auto.Register[money, moneyDTO](ap, auto.WithStructConstructor(newMoney, "Amount", "Code"))
+			},
+		},
+		{
+			name: "positional parameters",
+			opt: func(ap *auto.Provider) {
+				auto.Register[money, moneyDTO](ap, auto.WithStructConstructor(func(code string, amount int64) money {
+					m, _ := newMoney(amount, code)
+					return *m
+				}))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ap := auto.NewProvider()
+			tc.opt(ap)
+
+			m, err := mapper.New(ap)
+			require.NoError(t, err)
+
+			var dst money
+			require.NoError(t, m.Map(&dst, &moneyDTO{Code: "USD", Amount: "42", Note: "tip"}))
+			require.Equal(t, money{amount: 42, currency: "USD", Note: "tip"}, dst)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[money, moneyDTO](ap, auto.WithStructConstructor(newMoney, "Amount", "Code"))
+
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst money
+		err = m.Map(&dst, &moneyDTO{Amount: "42"})
+		require.EqualError(t, err, "constructing auto_test.money: currency is required")
+
+		err = m.Map(&dst, &moneyDTO{Code: "USD", Amount: "lots"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `mapping constructor parameter 0 from "auto_test.moneyDTO.Amount"`)
+	})
+
+	t.Run("missing member", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[money, moneyDTO](ap, auto.WithStructConstructor(newMoney, "Amount", "Currency"))
+
+		_, err := mapper.New(ap)
+		require.EqualError(t, err, `mapping money: constructor parameter 1: no member "Currency" on auto_test.moneyDTO`)
+	})
+
+	t.Run("map source", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[money, map[string]interface{}](ap, auto.WithStructConstructor(newMoney, "Amount", "Code"))
+
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst money
+		require.NoError(t, m.Map(&dst, map[string]interface{}{"amount": float64(42), "code": "USD", "note": "tip"}))
+		require.Equal(t, money{amount: 42, currency: "USD", Note: "tip"}, dst)
+	})
+
+	t.Run("map source without names", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[money, map[string]interface{}](ap, auto.WithStructConstructor(newMoney))
+
+		_, err := mapper.New(ap)
+		require.EqualError(t, err, `mapping money: constructor func(int64, string) (*auto_test.money, error) needs parameter names to map from map[string]interface {}, which has no fields in order`)
+	})
+}
+
+type aggregate struct {
+	id       int
+	customer *customerEntity
+	failure  error
+}
+
+func (a aggregate) GetID() int {
+	return a.id
+}
+
+func (a *aggregate) Customer() *customerEntity {
+	return a.customer
+}
+
+func (a aggregate) Status() (string, error) {
+	return "open", a.failure
+}
+
+func (a aggregate) FetchRegion() string {
+	return "west"
+}
+
+type customerEntity struct {
+	first string
+	last  string
+}
+
+func (c *customerEntity) FullName() string {
+	return c.first + " " + c.last
+}
+
+func TestGetters(t *testing.T) {
+	t.Parallel()
+	type aggregateDTO struct {
+		ID               string
+		CustomerFullName string
+		Status           string
+		Region           string
+	}
+
+	newMapper := func(t *testing.T, opts ...func(ap *auto.Provider)) *mapper.Mapper {
+		ap := auto.NewProvider()
+		for _, opt := range opts {
+			opt(ap)
+		}
+		auto.Register[aggregateDTO, aggregate](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+		return m
+	}
+
+	src := aggregate{id: 7, customer: &customerEntity{first: "Ada", last: "Lovelace"}}
+
+	t.Run("default prefix", func(t *testing.T) {
+		var dst aggregateDTO
+		require.NoError(t, newMapper(t).Map(&dst, src))
+		require.Equal(t, aggregateDTO{ID: "7", CustomerFullName: "Ada Lovelace", Status: "open"}, dst)
+	})
+
+	t.Run("configured prefixes", func(t *testing.T) {
+		m := newMapper(t, func(ap *auto.Provider) { ap.WithGetterPrefixes("Get", "Fetch") })
+
+		var dst aggregateDTO
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, "west", dst.Region)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		failing := src
+		failing.failure = errors.New("closed")
+
+		var dst aggregateDTO
+		err := newMapper(t).Map(&dst, &failing)
+		require.True(t, errors.Is(err, failing.failure), "expected %v, but got %v", failing.failure, err)
+		require.Contains(t, err.Error(), `"auto_test.aggregate.Status()"`)
+	})
+}
+
+type ledger struct {
+	balance int
+	Owner   string
+	Memo    string
+}
+
+func (l *ledger) SetBalance(balance int) error {
+	if balance < 0 {
+		return errors.New("balance cannot be negative")
+	}
+	l.balance = balance
+	return nil
+}
+
+func (l *ledger) SetOwner(owner string) {
+	l.Owner = strings.ToUpper(owner)
+}
+
+func (l ledger) WithMemo(memo string) ledger {
+	l.Memo = "memo: " + memo
+	return l
+}
+
+func TestSetters(t *testing.T) {
+	t.Parallel()
+	type ledgerDTO struct {
+		Balance string
+		Owner   string
+		Memo    string
+	}
+
+	newMapper := func(t *testing.T, prefixes ...string) *mapper.Mapper {
+		ap := auto.NewProvider()
+		ap.WithSetterPrefixes(prefixes...)
+		auto.Register[ledger, ledgerDTO](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var dst ledger
+		require.NoError(t, newMapper(t).Map(&dst, &ledgerDTO{Balance: "10", Owner: "ada", Memo: "hi"}))
+		require.Equal(t, ledger{Owner: "ada", Memo: "hi"}, dst)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		var dst ledger
+		require.NoError(t, newMapper(t, "Set", "With").Map(&dst, &ledgerDTO{Balance: "10", Owner: "ada", Memo: "hi"}))
+		require.Equal(t, ledger{balance: 10, Owner: "ADA", Memo: "memo: hi"}, dst)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var dst ledger
+		err := newMapper(t, "Set").Map(&dst, &ledgerDTO{Balance: "-1"})
+		require.EqualError(t, err, `mapping field "auto_test.ledger.SetBalance()" from "auto_test.ledgerDTO.Balance": calling SetBalance(): balance cannot be negative`)
+	})
+}
+
+func TestMaps(t *testing.T) {
+	t.Parallel()
+	type address struct {
+		City string
+	}
+	type item struct {
+		SKU string
+		Qty int
+	}
+	type order struct {
+		ID           int
+		CustomerName string
+		Address      *address
+		Items        []item
+		Stock        map[string]*item
+		Note         string `mapper:"name=Comment,omitempty"`
+	}
+
+	ap := auto.NewProvider()
+	auto.Register[order, map[string]interface{}](ap, auto.WithStructReverse())
+
+	m, err := mapper.New(ap)
+	require.NoError(t, err)
+
+	t.Run("from map", func(t *testing.T) {
+		var dst order
+		require.NoError(t, m.Map(&dst, map[string]interface{}{
+			"id":       float64(7),
+			"customer": map[string]interface{}{"name": "Ada"},
+			"address":  map[string]interface{}{"city": "London"},
+			"items": []interface{}{
+				map[string]interface{}{"sku": "A-1", "qty": "2"},
+			},
+			"comment": "fragile",
+		}))
+		require.Equal(t, order{
+			ID:           7,
+			CustomerName: "Ada",
+			Address:      &address{City: "London"},
+			Items:        []item{{SKU: "A-1", Qty: 2}},
+			Note:         "fragile",
+		}, dst)
+	})
+
+	t.Run("to map", func(t *testing.T) {
+		var dst map[string]interface{}
+		require.NoError(t, m.Map(&dst, &order{
+			ID:           7,
+			CustomerName: "Ada",
+			Address:      &address{City: "London"},
+		}))
+		require.Equal(t, map[string]interface{}{
+			"ID":           7,
+			"CustomerName": "Ada",
+			"Address":      map[string]interface{}{"City": "London"},
+		}, dst)
+	})
+
+	t.Run("collections to map", func(t *testing.T) {
+		src := order{
+			ID:    7,
+			Items: []item{{SKU: "A-1", Qty: 2}},
+			Stock: map[string]*item{"A-1": {SKU: "A-1", Qty: 5}},
+		}
+
+		var dst map[string]interface{}
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, map[string]interface{}{
+			"ID":           7,
+			"CustomerName": "",
+			"Items":        []interface{}{map[string]interface{}{"SKU": "A-1", "Qty": 2}},
+			"Stock":        map[string]interface{}{"A-1": map[string]interface{}{"SKU": "A-1", "Qty": 5}},
+		}, dst)
+
+		var back order
+		require.NoError(t, m.Map(&back, dst))
+		require.Equal(t, src, back)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var dst order
+		err := m.Map(&dst, map[string]interface{}{
+			"address": map[string]interface{}{"city": []interface{}{"London"}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `mapping field "auto_test.order.Address" from "map[string]interface {}[\"address\"]"`)
+		require.Contains(t, err.Error(), `mapping field "auto_test.address.City" from "map[string]interface {}[\"city\"]"`)
+	})
+
+	t.Run("nested key path", func(t *testing.T) {
+		var dst order
+		err := m.Map(&dst, map[string]interface{}{
+			"customer": map[string]interface{}{"name": []interface{}{"Ada"}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `from "map[string]interface {}[\"customer\"][\"name\"]"`)
+	})
+
+	t.Run("ambiguous keys", func(t *testing.T) {
+		var dst order
+		err := m.Map(&dst, map[string]interface{}{
+			"customer_name": "Ada",
+			"customerName":  "Bob",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `keys "customerName", "customer_name" are ambiguous for CustomerName`)
+	})
+
+	t.Run("exact key first", func(t *testing.T) {
+		var dst order
+		require.NoError(t, m.Map(&dst, map[string]interface{}{
+			"CustomerName":  "Ada",
+			"customer_name": "Bob",
+		}))
+		require.Equal(t, "Ada", dst.CustomerName)
+	})
+}
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+	type address struct {
+		Town string `mapper:"name=City"`
+	}
+	type line struct {
+		SKU string
+	}
+	type customer struct {
+		Addresses []address
+	}
+	type order struct {
+		Customer   *customer
+		Attributes map[string]string
+		Lines      []line
+	}
+	type orderDTO struct {
+		City     string
+		Color    string `mapper:"from=Attributes['color']"`
+		Shade    string `mapper:"from=Attributes.shade"`
+		LastSKU  string `mapper:"from=Lines[-1].SKU"`
+		FirstSKU string
+	}
+
+	newMapper := func(t *testing.T, opts ...accessor.PathOption) (*mapper.Mapper, error) {
+		ap := auto.NewProvider()
+		auto.Register[orderDTO, order](ap,
+			auto.WithStructField("City", auto.WithFieldBinder(accessor.Path("Customer.Addresses[0].City"))),
+			auto.WithStructField("FirstSKU", auto.WithFieldBinder(accessor.Path("Lines[0].SKU", opts...))),
+		)
+		return mapper.New(ap)
+	}
+
+	t.Run("values", func(t *testing.T) {
+		m, err := newMapper(t)
+		require.NoError(t, err)
+
+		var dst orderDTO
+		require.NoError(t, m.Map(&dst, &order{
+			Customer:   &customer{Addresses: []address{{Town: "Paris"}, {Town: "Rome"}}},
+			Attributes: map[string]string{"color": "red", "shade": "dark"},
+			Lines:      []line{{SKU: "A"}, {SKU: "B"}},
+		}))
+		require.Equal(t, orderDTO{City: "Paris", Color: "red", Shade: "dark", LastSKU: "B", FirstSKU: "A"}, dst)
+	})
+
+	t.Run("nil and out of range", func(t *testing.T) {
+		m, err := newMapper(t)
+		require.NoError(t, err)
+
+		var dst orderDTO
+		require.NoError(t, m.Map(&dst, &order{Customer: &customer{}}))
+		require.Equal(t, orderDTO{}, dst)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		m, err := newMapper(t, accessor.WithPathStrict())
+		require.NoError(t, err)
+
+		var dst orderDTO
+		err = m.Map(&dst, &order{Lines: []line{}})
+		require.EqualError(t, err, `mapping field "auto_test.orderDTO.FirstSKU" from "auto_test.order.Lines[0].SKU": index 0 out of range with length 0`)
+	})
+
+	t.Run("exported names", func(t *testing.T) {
+		acc, err := accessor.Path("Customer.Addresses[0].Town").Bind(reflect.TypeOf(order{}), nil)
+		require.NoError(t, err)
+		require.Equal(t, reflect.TypeOf(""), acc.Type())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[orderDTO, order](ap,
+			auto.WithStructField("City", auto.WithFieldBinder(accessor.Path("Customer.Addresses.City"))),
+		)
+
+		_, err := mapper.New(ap)
+		require.EqualError(t, err, `mapping field "orderDTO.City": path "Customer.Addresses.City": member "City" does not exist on []auto_test.address`)
+	})
+}
+
+func TestEmbedded(t *testing.T) {
+	t.Parallel()
+	type Audit struct {
+		CreatedBy string
+		UpdatedBy string
+	}
+	type Owner struct {
+		Name string
+	}
+	type Label struct {
+		Name string
+	}
+	type Heading struct {
+		Text string `mapper:"name=Title"`
+	}
+	type Caption struct {
+		Value string `mapper:"name=Title"`
+	}
+	type document struct {
+		Title     string
+		CreatedBy string
+		UpdatedBy string
+		Name      string
+	}
+	type documentDTO struct {
+		Audit
+		*Owner
+		Title string
+	}
+	type labelledDTO struct {
+		*Owner
+		Label
+		Title string
+	}
+	type record struct {
+		*Audit
+		Title string
+	}
+	type captioned struct {
+		Heading
+		Caption
+	}
+
+	t.Run("flatten", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[documentDTO, document](ap, auto.WithStructFlattenEmbedded(), auto.WithStructReverse())
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		src := document{Title: "Plan", CreatedBy: "ann", UpdatedBy: "bob", Name: "cat"}
+		var dst documentDTO
+		require.NoError(t, m.Map(&dst, &src))
+		require.Equal(t, documentDTO{Audit: Audit{CreatedBy: "ann", UpdatedBy: "bob"}, Owner: &Owner{Name: "cat"}, Title: "Plan"}, dst)
+
+		var back document
+		require.NoError(t, m.Map(&back, &dst))
+		require.Equal(t, src, back)
+
+		dst = documentDTO{}
+		require.NoError(t, m.Map(&dst, &document{Title: "Plan"}))
+		require.Nil(t, dst.Owner)
+
+		dst = documentDTO{Audit: Audit{CreatedBy: "old"}, Owner: &Owner{Name: "old"}}
+		require.NoError(t, m.Map(&dst, &document{Title: "Plan"}))
+		require.Equal(t, documentDTO{Owner: &Owner{}, Title: "Plan"}, dst)
+
+		dst = documentDTO{}
+		require.NoError(t, m.Map(&back, &dst))
+		require.Equal(t, document{}, back)
+	})
+
+	t.Run("flatten from provider", func(t *testing.T) {
+		ap := auto.NewProvider()
+		ap.WithFlattenEmbedded(true)
+		auto.Register[documentDTO, document](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst documentDTO
+		require.NoError(t, m.Map(&dst, &document{CreatedBy: "ann"}))
+		require.Equal(t, "ann", dst.CreatedBy)
+	})
+
+	t.Run("not flattened", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[documentDTO, document](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst documentDTO
+		require.NoError(t, m.Map(&dst, &document{Title: "Plan", CreatedBy: "ann", Name: "cat"}))
+		require.Equal(t, documentDTO{Title: "Plan"}, dst)
+	})
+
+	t.Run("ambiguous destination", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[labelledDTO, document](ap, auto.WithStructFlattenEmbedded())
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst labelledDTO
+		require.NoError(t, m.Map(&dst, &document{Title: "Plan", Name: "cat"}))
+		require.Equal(t, labelledDTO{Title: "Plan"}, dst)
+	})
+
+	t.Run("embedded source", func(t *testing.T) {
+		ap := auto.NewProvider()
+		auto.Register[document, record](ap)
+		auto.Register[document, captioned](ap)
+		m, err := mapper.New(ap)
+		require.NoError(t, err)
+
+		var dst document
+		require.NoError(t, m.Map(&dst, &record{Title: "Plan"}))
+		require.Equal(t, document{Title: "Plan"}, dst)
+
+		require.NoError(t, m.Map(&dst, &record{Audit: &Audit{CreatedBy: "ann"}, Title: "Plan"}))
+		require.Equal(t, document{Title: "Plan", CreatedBy: "ann"}, dst)
+
+		dst = document{}
+		require.NoError(t, m.Map(&dst, &captioned{Heading: Heading{Text: "a"}, Caption: Caption{Value: "b"}}))
+		require.Equal(t, document{}, dst)
+	})
+}
@@ -0,0 +1,100 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
+)
+
+// tagKey is the struct tag key holding mapping configuration.
+const tagKey = "mapper"
+
+// tag is the parsed form of a mapper struct tag. A tag of "-" ignores the field. Otherwise, the tag is a
+// comma separated list of options:
+//
+//	name=<name>    matches the field using name rather than the field's name
+//	from=<path>    reads a destination field from a path expression on the source, see accessor.Path
+//	converter=<n>  converts a destination field with the converter registered as n
+//	omitempty      leaves a destination field untouched when the source value is empty
+//
+// The from, converter and omitempty options only apply to destination fields.
+type tag struct {
+	ignore    bool
+	name      string
+	from      string
+	converter string
+	omitEmpty bool
+}
+
+func parseTag(fld reflect.StructField) (tag, error) {
+	var t tag
+	value, ok := fld.Tag.Lookup(tagKey)
+	if !ok || value == "" {
+		return t, nil
+	}
+
+	if value == "-" {
+		t.ignore = true
+		return t, nil
+	}
+
+	for _, opt := range strings.Split(value, ",") {
+		opt = strings.TrimSpace(opt)
+		key, arg, hasArg := strings.Cut(opt, "=")
+		switch {
+		case key == "omitempty" && !hasArg:
+			t.omitEmpty = true
+		case key == "name" && hasArg:
+			t.name = arg
+		case key == "from" && hasArg:
+			t.from = arg
+		case key == "converter" && hasArg:
+			t.converter = arg
+		default:
+			return t, fmt.Errorf("invalid %s tag option %q on field %q", tagKey, opt, fld.Name)
+		}
+	}
+
+	return t, nil
+}
+
+// memberName returns the name of fld, as renamed by its tag.
+func (t tag) memberName(fld reflect.StructField) string {
+	if t.name != "" {
+		return t.name
+	}
+	return fld.Name
+}
+
+// taggedField creates a Field from the tag on the destination field fld.
+func (p *Provider) taggedField(s *Struct, fld reflect.StructField) (*Field, tag, error) {
+	t, err := parseTag(fld)
+	if err != nil {
+		return nil, t, err
+	}
+
+	f := Field{
+		dst:       fld,
+		ignore:    t.ignore,
+		omitEmpty: t.omitEmpty,
+	}
+
+	if t.from != "" {
+		f.accessor, err = accessor.Path(t.from).Bind(s.src, fieldByName)
+		if err != nil {
+			return nil, t, fmt.Errorf("field %q: %w", fld.Name, err)
+		}
+	}
+
+	if t.converter != "" {
+		c, ok := p.converters[t.converter]
+		if !ok {
+			return nil, t, fmt.Errorf("field %q: no converter named %q", fld.Name, t.converter)
+		}
+		f.converter = c
+	}
+
+	return &f, t, nil
+}
@@ -0,0 +1,95 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/auto/naming"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// unflatten creates members for the fields of the struct at the end of path using flattened names from the
+// src, such that Customer.Name is populated from CustomerName, where prefix is the flattened name of the path.
+// Nested structs are unflattened recursively. The unmapped nested fields are only returned when at least one
+// member was created.
+func (p *Provider) unflatten(ns naming.Strategy, cf converter.Factory, s *Struct, path []reflect.StructField, prefix string) ([]*member, []string, error) {
+	t := internal.UnwrapPtrType(path[len(path)-1].Type)
+	if t.Kind() != reflect.Struct || isRecursive(s.dst, path, t) {
+		return nil, nil, nil
+	}
+
+	var members []*member
+	var unmapped []string
+	for i := 0; i < t.NumField(); i++ {
+		fld := t.Field(i)
+		if fld.PkgPath != "" {
+			continue
+		}
+
+		tg, err := parseTag(fld)
+		if err != nil {
+			return nil, nil, fmt.Errorf("mapping %v: %w", s.dst.Name(), err)
+		}
+		if tg.ignore {
+			continue
+		}
+
+		fldPath := append(path[:len(path):len(path)], fld)
+		name := prefix + tg.memberName(fld)
+		acc, err := findAccessor(ns, p.getterPrefixes, name, s.src)
+		if err != nil {
+			return nil, nil, fmt.Errorf("mapping field %q: %w", fmt.Sprintf("%v.%s", s.dst.Name(), pathName(fldPath)), err)
+		}
+		if acc == nil {
+			nested, nestedUnmapped, err := p.unflatten(ns, cf, s, fldPath, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(nested) == 0 {
+				unmapped = append(unmapped, fmt.Sprintf("%v.%s", s.dst.Name(), pathName(fldPath)))
+				continue
+			}
+
+			members = append(members, nested...)
+			unmapped = append(unmapped, nestedUnmapped...)
+			continue
+		}
+
+		mapFn, err := mapFuncFor(cf, fld.Type, acc.Type())
+		if err != nil {
+			return nil, nil, fmt.Errorf("mapping field %q from %q: %w",
+				fmt.Sprintf("%v.%s", s.dst.Name(), pathName(fldPath)),
+				fmt.Sprintf("%v.%s", typeName(s.src), acc.Name()),
+				err)
+		}
+
+		members = append(members, &member{
+			path:      fldPath,
+			accessor:  acc,
+			mapFn:     mapFn,
+			omitEmpty: tg.omitEmpty,
+		})
+	}
+
+	if len(members) == 0 {
+		return nil, nil, nil
+	}
+
+	return members, unmapped, nil
+}
+
+// isRecursive reports whether t has already been visited along the path from dst.
+func isRecursive(dst reflect.Type, path []reflect.StructField, t reflect.Type) bool {
+	if t == dst {
+		return true
+	}
+
+	for _, fld := range path[:len(path)-1] {
+		if internal.UnwrapPtrType(fld.Type) == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,13 +1,23 @@
 package auto
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/craiggwilson/go-mapper/pkg/auto/accessor"
 	"github.com/craiggwilson/go-mapper/pkg/auto/naming"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
 )
 
-func findAccessor(ns naming.Strategy, name string, src reflect.Type) accessor.Accessor {
+// findAccessor returns an accessor for the member of src known by name, following the possibilities of the
+// naming strategy through fields and getter methods, where a getter may also be named with one of the prefixes.
+func findAccessor(ns naming.Strategy, prefixes []string, name string, src reflect.Type) (accessor.Accessor, error) {
+	if t := internal.UnwrapPtrType(src); isStringMap(t) {
+		// Keys are only known once there is a map to look in.
+		return accessor.MapKey(ns, name, t.Elem()), nil
+	}
+
 	var currentAccessor accessor.Accessor
 	lastName := name
 	currentName := name
@@ -18,15 +28,18 @@ func findAccessor(ns naming.Strategy, name string, src reflect.Type) accessor.Ac
 		}
 
 		for _, p := range ns.Possibilities(currentName) {
-			fld, found := currentType.FieldByName(p.Match)
-			if !found {
+			next, err := memberAccessor(currentType, prefixes, p.Match)
+			if err != nil {
+				return nil, err
+			}
+			if next == nil {
 				continue
 			}
 
 			if currentAccessor == nil {
-				currentAccessor = accessor.Field(fld)
+				currentAccessor = next
 			} else {
-				currentAccessor = accessor.Pair(currentAccessor, accessor.Field(fld))
+				currentAccessor = accessor.Pair(currentAccessor, next)
 			}
 
 			lastName = currentName
@@ -37,9 +50,153 @@ func findAccessor(ns naming.Strategy, name string, src reflect.Type) accessor.Ac
 
 		// we made no progress, so we are done
 		if lastName == currentName {
-			return nil
+			return nil, nil
+		}
+	}
+
+	return currentAccessor, nil
+}
+
+// memberAccessor returns an accessor for the field of t known by name or, failing that, a getter method
+// named name, or name following one of the prefixes.
+func memberAccessor(t reflect.Type, prefixes []string, name string) (accessor.Accessor, error) {
+	if t.Kind() == reflect.Struct {
+		fld, found, err := fieldByName(t, name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return accessor.Field(fld), nil
+		}
+	}
+
+	if t.Kind() == reflect.Interface {
+		return nil, nil
+	}
+
+	pt := reflect.PtrTo(t)
+	for i := -1; i < len(prefixes); i++ {
+		methodName := name
+		if i >= 0 {
+			methodName = prefixes[i] + name
+		}
+
+		if m, found := pt.MethodByName(methodName); found && accessor.IsGetter(m) {
+			return accessor.Method(m), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// fieldPath returns the struct fields read by acc, provided it reads nothing but struct fields.
+func fieldPath(acc accessor.Accessor) ([]reflect.StructField, bool) {
+	switch a := acc.(type) {
+	case *accessor.FieldAccessor:
+		return []reflect.StructField{a.Field()}, true
+	case *accessor.PairAccessor:
+		first, ok := fieldPath(a.First())
+		if !ok {
+			return nil, false
+		}
+		second, ok := fieldPath(a.Second())
+		if !ok {
+			return nil, false
+		}
+		return append(first, second...), true
+	default:
+		return nil, false
+	}
+}
+
+// pathAccessor returns an accessor that reads the struct fields in path.
+func pathAccessor(path []reflect.StructField) accessor.Accessor {
+	var acc accessor.Accessor = accessor.Field(path[0])
+	for _, fld := range path[1:] {
+		acc = accessor.Pair(acc, accessor.Field(fld))
+	}
+	return acc
+}
+
+// fieldByName returns the exported field of the struct t known by name, honouring tags that rename or ignore
+// fields. As with Go's promotion rules, the least nested field known by name hides the others, and nothing is
+// returned when more than one field is known by name at that depth. A malformed tag on any of the fields results
+// in an error.
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool, error) {
+	var found reflect.StructField
+	ok := false
+	ambiguous := false
+	for _, fld := range reflect.VisibleFields(t) {
+		if !fld.IsExported() {
+			continue
+		}
+
+		tg, err := parseTag(fld)
+		if err != nil {
+			return reflect.StructField{}, false, fmt.Errorf("reading %v: %w", t, err)
+		}
+		if tg.ignore || tg.memberName(fld) != name {
+			continue
+		}
+
+		switch {
+		case !ok || len(fld.Index) < len(found.Index):
+			found = fld
+			ok = true
+			ambiguous = false
+		case len(fld.Index) == len(found.Index):
+			ambiguous = true
+		}
+	}
+
+	return found, ok && !ambiguous, nil
+}
+
+// promotedFields returns the exported fields of the struct t, with the fields of embedded structs promoted in
+// place of the embedded struct following Go's promotion rules. An embedded struct is kept as a field of its own
+// when it has no exported fields to promote, and is skipped along with its fields when skip reports true for it
+// or it is an unexported pointer, which cannot be allocated. An error from skip is returned.
+func promotedFields(t reflect.Type, skip func(reflect.StructField) (bool, error)) ([]reflect.StructField, error) {
+	var fields []reflect.StructField
+	var skipped [][]int
+	for _, fld := range reflect.VisibleFields(t) {
+		if hasPrefix(fld.Index, skipped) {
+			continue
+		}
+
+		if fld.Anonymous && isNestedStruct(internal.UnwrapPtrType(fld.Type)) {
+			skipping, err := skip(fld)
+			if err != nil {
+				return nil, err
+			}
+			if skipping || !fld.IsExported() && fld.Type.Kind() == reflect.Ptr {
+				skipped = append(skipped, fld.Index)
+			}
+			continue
+		}
+
+		if fld.IsExported() {
+			fields = append(fields, fld)
 		}
 	}
 
-	return currentAccessor
+	return fields, nil
+}
+
+// hasPrefix reports whether index begins with any of the prefixes.
+func hasPrefix(index []int, prefixes [][]int) bool {
+	for _, prefix := range prefixes {
+		if len(prefix) <= len(index) && reflect.DeepEqual(prefix, index[:len(prefix)]) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathName(path []reflect.StructField) string {
+	names := make([]string, len(path))
+	for i, fld := range path {
+		names[i] = fld.Name
+	}
+	return strings.Join(names, ".")
 }
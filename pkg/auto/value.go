@@ -0,0 +1,70 @@
+package auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/auto/converter"
+	"github.com/craiggwilson/go-mapper/pkg/core"
+	"github.com/craiggwilson/go-mapper/pkg/internal"
+)
+
+// mapFuncFor returns a function that maps a value of type src into a pointer to a value of type dst. A
+// converter is used when the converter factory provides one. Otherwise, collections are mapped element by
+// element, the values held by interfaces are mapped according to their type, and structs, along with the maps
+// they are mapped to and from, are handed to the core.Context so that whichever mapper is registered for the
+// pair can do the work.
+func mapFuncFor(cf converter.Factory, dst reflect.Type, src reflect.Type) (core.MapperFunc, error) {
+	conv, err := cf.ConverterFor(dst, src)
+	if err == nil {
+		return convertFunc(conv), nil
+	}
+
+	bareDst := internal.UnwrapPtrType(dst)
+	bareSrc := internal.UnwrapPtrType(src)
+	switch {
+	case bareSrc.Kind() == reflect.Interface:
+		return dynamicFunc(cf, dst), nil
+	case bareDst.Kind() == reflect.Struct && bareSrc.Kind() == reflect.Struct,
+		bareDst.Kind() == reflect.Struct && isStringMap(bareSrc),
+		isStringMap(bareDst) && bareSrc.Kind() == reflect.Struct:
+		return mapNested, nil
+	case isList(bareDst) && isList(bareSrc):
+		return listFunc(cf, bareDst, bareSrc)
+	case bareDst.Kind() == reflect.Map && bareSrc.Kind() == reflect.Map:
+		return mapFunc(cf, bareDst, bareSrc)
+	default:
+		return nil, err
+	}
+}
+
+// convertFunc returns a function that uses conv to map src into dst. When conv is nil, src is assigned
+// directly to dst.
+func convertFunc(conv converter.Converter) core.MapperFunc {
+	return func(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+		if internal.IsNil(src) {
+			return nil
+		}
+
+		if conv != nil {
+			return conv.Convert(dst, src)
+		}
+
+		dst = internal.EnsureSettableDst(dst)
+		dst.Set(internal.UnwrapPtrValue(src))
+		return nil
+	}
+}
+
+// mapNested maps src into dst using the mapper registered with the core.Context.
+func mapNested(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+	if internal.IsNil(src) {
+		return nil
+	}
+
+	if ctx == nil {
+		return fmt.Errorf("no context available to map %v from %v", dst.Type(), src.Type())
+	}
+
+	return ctx.Map(dst, src)
+}
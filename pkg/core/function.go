@@ -55,6 +55,28 @@ func MapperFromFunc(fn interface{}) *FunctionMapper {
 	return NewFunctionMapper(t.In(argPos), t.In(argPos +1), mapFn)
 }
 
+// MapperFromTypedFunc creates a Mapper from a strongly typed function. Unlike MapperFromFunc, fn is invoked
+// directly rather than through reflection.
+func MapperFromTypedFunc[Dst, Src any](fn func(ctx Context, dst Dst, src Src) error) *FunctionMapper {
+	tDst := reflect.TypeOf((*Dst)(nil)).Elem()
+	tSrc := reflect.TypeOf((*Src)(nil)).Elem()
+
+	mapFn := func(ctx Context, dst reflect.Value, src reflect.Value) error {
+		d, ok := valueAs[Dst](dst)
+		if !ok {
+			return fmt.Errorf("dst must be a %v, but was %v", tDst, dst.Type())
+		}
+		s, ok := valueAs[Src](src)
+		if !ok {
+			return fmt.Errorf("src must be a %v, but was %v", tSrc, src.Type())
+		}
+
+		return fn(ctx, d, s)
+	}
+
+	return NewFunctionMapper(tDst, tSrc, mapFn)
+}
+
 // NewFunctionMapper makes a FunctionMapper.
 func NewFunctionMapper(dst reflect.Type, src reflect.Type, mapFn MapperFunc) *FunctionMapper {
 	return &FunctionMapper{
@@ -30,3 +30,34 @@ func TestFunctionTypeMapper(t *testing.T) {
 		t.Fatalf("expected 42, but got %v", i)
 	}
 }
+
+func TestTypedFunctionTypeMapper(t *testing.T) {
+	tm := core.MapperFromTypedFunc(func(_ core.Context, dst *int, src string) error {
+		i, err := strconv.Atoi(src)
+		if err != nil {
+			return err
+		}
+
+		*dst = i
+		return nil
+	})
+
+	if tm.Dst() != reflect.TypeOf(new(int)) {
+		t.Fatalf("expected dst type *int, but got %v", tm.Dst())
+	}
+
+	var i int
+	err := tm.Map(nil, reflect.ValueOf(&i), reflect.ValueOf("42"))
+	if err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+
+	if i != 42 {
+		t.Fatalf("expected 42, but got %v", i)
+	}
+
+	err = tm.Map(nil, reflect.ValueOf(&i), reflect.ValueOf(42))
+	if err == nil {
+		t.Fatalf("expected an error, but got none")
+	}
+}
@@ -7,4 +7,14 @@ import (
 var (
 	tContext        = reflect.TypeOf((*Context)(nil)).Elem()
 	tErr            = reflect.TypeOf((*error)(nil)).Elem()
-)
\ No newline at end of file
+)
+
+func valueAs[T any](v reflect.Value) (T, bool) {
+	if !v.IsValid() {
+		var zero T
+		return zero, true
+	}
+
+	t, ok := v.Interface().(T)
+	return t, ok
+}
@@ -15,7 +15,7 @@ func EnsureSettableDst(dst reflect.Value) reflect.Value {
 
 	dst = dst.Elem()
 	for dst.Kind() == reflect.Ptr {
-		if !dst.Elem().IsValid() || dst.Elem().IsNil() {
+		if dst.IsNil() {
 			nv := reflect.New(dst.Type().Elem())
 			dst.Set(nv)
 		}
@@ -30,6 +30,24 @@ func EnsureSettableDst(dst reflect.Value) reflect.Value {
 	return dst
 }
 
+// FieldByIndex returns the nested field of the struct v at index, allocating any nil embedded pointers along
+// the way, which must be settable.
+func FieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
 func UnwrapPtrValue(v reflect.Value) reflect.Value {
 	for v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -43,4 +61,16 @@ func UnwrapPtrType(t reflect.Type) reflect.Type {
 		t = t.Elem()
 	}
 	return t
-}
\ No newline at end of file
+}
+
+// IsNil reports whether v is invalid or a nil value of a kind that can be nil.
+func IsNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
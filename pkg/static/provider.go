@@ -15,8 +15,8 @@ type Provider struct {
 }
 
 // Mappers implements the core.Provider interface.
-func (p *Provider) Mappers() []core.Mapper {
-	return p.tms
+func (p *Provider) Mappers() ([]core.Mapper, error) {
+	return p.tms, nil
 }
 
 // Add adds a Mapper to the static mapping list.
@@ -0,0 +1,198 @@
+package mapper
+
+import (
+	"reflect"
+
+	"github.com/craiggwilson/go-mapper/pkg/core"
+)
+
+// resolve returns the mapper for the dst and src types that is found by scanning the registered mappers, as in
+// steps 3 and 4 of lookup, or nil when there is none. The result is cached by type, so that only the first
+// lookup of a pair of types scans.
+func (m *Mapper) resolve(dst reflect.Type, src reflect.Type) core.Mapper {
+	key := typePair{dst, src}
+	if tm, ok := m.resolved.Load(key); ok {
+		if tm == nil {
+			return nil
+		}
+		return tm.(core.Mapper)
+	}
+
+	tm := m.scan(dst, src)
+	m.resolved.Store(key, tm)
+	return tm
+}
+
+// scan returns the first registered mapper for the dst type whose src type is an interface implemented by src
+// or, when assignable destinations are enabled, whose dst type is assignable to dst.
+func (m *Mapper) scan(dst reflect.Type, src reflect.Type) core.Mapper {
+	bareDst := unwrapPtrType(dst)
+	bareSrc := unwrapPtrType(src)
+	for _, tm := range m.mappers {
+		if unwrapPtrType(tm.Dst()) == bareDst && implements(src, tm.Src()) {
+			return tm
+		}
+	}
+
+	if m.assignableDst {
+		for _, tm := range m.mappers {
+			if unwrapPtrType(tm.Src()) != bareSrc && !implements(src, tm.Src()) {
+				continue
+			}
+
+			if am, ok := newAssignMapper(bareDst, tm); ok {
+				return am
+			}
+		}
+	}
+
+	return nil
+}
+
+// newAssignMapper makes an assignMapper when the bare dst type of tm can be assigned to dst.
+func newAssignMapper(dst reflect.Type, tm core.Mapper) (*assignMapper, bool) {
+	inner := unwrapPtrType(tm.Dst())
+	switch {
+	case inner == dst:
+		return nil, false
+	case inner.AssignableTo(dst):
+		return &assignMapper{dst: dst, tm: tm}, true
+	case reflect.PtrTo(inner).AssignableTo(dst):
+		return &assignMapper{dst: dst, tm: tm, ptr: true}, true
+	default:
+		return nil, false
+	}
+}
+
+// assignMapper maps into a new value of the dst type of a core.Mapper and assigns the result to a different,
+// but assignable, type.
+type assignMapper struct {
+	dst reflect.Type
+	tm  core.Mapper
+	ptr bool
+}
+
+// Dst implements the core.Mapper interface.
+func (am *assignMapper) Dst() reflect.Type {
+	return am.dst
+}
+
+// Src implements the core.Mapper interface.
+func (am *assignMapper) Src() reflect.Type {
+	return am.tm.Src()
+}
+
+// Map implements the core.Mapper interface.
+func (am *assignMapper) Map(ctx core.Context, dst reflect.Value, src reflect.Value) error {
+	v := reflect.New(unwrapPtrType(am.tm.Dst()))
+	if err := am.tm.Map(ctx, adaptDst(v, am.tm.Dst()), src); err != nil {
+		return err
+	}
+
+	if am.ptr {
+		dst.Elem().Set(v)
+	} else {
+		dst.Elem().Set(v.Elem())
+	}
+	return nil
+}
+
+// adaptDst adjusts the pointer dst to the pointer depth expected by a mapper registered with the dst type
+// target, allocating any nil pointers along the way.
+func adaptDst(dst reflect.Value, target reflect.Type) reflect.Value {
+	if target.Kind() != reflect.Ptr {
+		target = reflect.PtrTo(target)
+	}
+
+	want := ptrDepth(target)
+	for ptrDepth(dst.Type()) > want {
+		if dst.Elem().IsNil() {
+			dst.Elem().Set(reflect.New(dst.Type().Elem().Elem()))
+		}
+		dst = dst.Elem()
+	}
+
+	for ptrDepth(dst.Type()) < want {
+		dst = addr(dst)
+	}
+
+	return dst
+}
+
+// adaptSrc adjusts src to the pointer depth expected by a mapper registered with the src type target. It
+// returns false when a nil pointer leaves nothing to map.
+func adaptSrc(src reflect.Value, target reflect.Type) (reflect.Value, bool) {
+	if target.Kind() == reflect.Interface {
+		for {
+			if src.Kind() == reflect.Ptr && src.IsNil() {
+				return src, false
+			}
+			if src.Type().Implements(target) {
+				return src, true
+			}
+			if src.Kind() != reflect.Ptr {
+				// Only the pointer implements the interface.
+				return addr(src), true
+			}
+			src = src.Elem()
+		}
+	}
+
+	want := ptrDepth(target)
+	for ptrDepth(src.Type()) > want {
+		if src.IsNil() {
+			return src, false
+		}
+		src = src.Elem()
+	}
+
+	for ptrDepth(src.Type()) < want {
+		src = addr(src)
+	}
+
+	return src, true
+}
+
+// implements reports whether src, or a pointer to its bare type, implements the interface iface.
+func implements(src reflect.Type, iface reflect.Type) bool {
+	if iface.Kind() != reflect.Interface {
+		return false
+	}
+
+	for {
+		if src.Implements(iface) {
+			return true
+		}
+		if src.Kind() != reflect.Ptr {
+			return reflect.PtrTo(src).Implements(iface)
+		}
+		src = src.Elem()
+	}
+}
+
+// addr returns a pointer to v, copying v when it is not addressable.
+func addr(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+func ptrDepth(t reflect.Type) int {
+	depth := 0
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		depth++
+	}
+	return depth
+}
+
+func unwrapPtrType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}